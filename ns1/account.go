@@ -0,0 +1,210 @@
+package ns1
+
+import (
+	"fmt"
+	"net/http"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// The vendored ns1-go account models predate several permission families and
+// IP whitelists, and round tripping a user, API key or team through them would
+// drop those. The account endpoints used by the provider are therefore
+// implemented here on top of its request helpers, with models that extend the
+// vendored ones.
+
+// accountService handles the 'account/users', 'account/apikeys' and
+// 'account/teams' endpoints.
+type accountService struct {
+	client *ns1.Client
+}
+
+func newAccountService(client *ns1.Client) *accountService {
+	return &accountService{client: client}
+}
+
+// permissionsMap extends account.PermissionsMap with the permission families
+// missing from the vendored client.
+type permissionsMap struct {
+	DNS        permissionsDNS                `json:"dns"`
+	Data       account.PermissionsData       `json:"data"`
+	Account    permissionsAccount            `json:"account"`
+	Monitoring account.PermissionsMonitoring `json:"monitoring"`
+	Security   permissionsSecurity           `json:"security"`
+	DHCP       permissionsDHCP               `json:"dhcp"`
+	IPAM       permissionsIPAM               `json:"ipam"`
+}
+
+// permissionsDNS extends account.PermissionsDNS with per record scoping.
+type permissionsDNS struct {
+	account.PermissionsDNS
+	RecordsAllow []permissionsRecord `json:"records_allow"`
+	RecordsDeny  []permissionsRecord `json:"records_deny"`
+}
+
+// permissionsRecord scopes DNS permissions to a record, or to the record and
+// its subdomains.
+type permissionsRecord struct {
+	Domain     string `json:"domain"`
+	Subdomains bool   `json:"subdomains"`
+	Zone       string `json:"zone"`
+	RecordType string `json:"type"`
+}
+
+// permissionsAccount extends account.PermissionsAccount.
+type permissionsAccount struct {
+	account.PermissionsAccount
+	ManageIPWhitelist bool `json:"manage_ip_whitelist"`
+}
+
+// permissionsSecurity holds the security permissions.
+type permissionsSecurity struct {
+	ManageGlobal2FA       bool `json:"manage_global_2fa"`
+	ManageActiveDirectory bool `json:"manage_active_directory"`
+}
+
+// permissionsDHCP holds the DHCP permissions of DDI accounts.
+type permissionsDHCP struct {
+	ManageDHCP bool `json:"manage_dhcp"`
+	ViewDHCP   bool `json:"view_dhcp"`
+}
+
+// permissionsIPAM holds the IPAM permissions of DDI accounts.
+type permissionsIPAM struct {
+	ManageIPAM bool `json:"manage_ipam"`
+	ViewIPAM   bool `json:"view_ipam"`
+}
+
+// accountUser extends account.User with the extended permissions and the IP
+// whitelist.
+type accountUser struct {
+	account.User
+	ipWhitelist
+	Permissions permissionsMap `json:"permissions"`
+}
+
+// accountAPIKey extends account.APIKey with the extended permissions and the IP
+// whitelist.
+type accountAPIKey struct {
+	account.APIKey
+	ipWhitelist
+	Permissions permissionsMap `json:"permissions"`
+}
+
+// accountTeam extends account.Team with the extended permissions and the IP
+// whitelist.
+type accountTeam struct {
+	account.Team
+	ipWhitelist
+	Permissions permissionsMap `json:"permissions"`
+}
+
+// accountTeamIDs holds the teams of a user or API key.
+type accountTeamIDs struct {
+	TeamIDs []string `json:"teams"`
+}
+
+// do sends the request and decodes the response into v.
+func (s *accountService) do(method, path string, body, v interface{}) (*http.Response, error) {
+	req, err := s.client.NewRequest(method, path, body)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(req, v)
+}
+
+// GetUser takes a username and returns the user.
+//
+// NS1 API docs: https://ns1.com/api/#users-user-get
+func (s *accountService) GetUser(username string) (*accountUser, *http.Response, error) {
+	var u accountUser
+	resp, err := s.do("GET", fmt.Sprintf("account/users/%s", username), nil, &u)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &u, resp, nil
+}
+
+// CreateUser creates the user, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#users-put
+func (s *accountService) CreateUser(u *accountUser) (*http.Response, error) {
+	return s.do("PUT", "account/users", u, u)
+}
+
+// UpdateUser updates the user, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#users-user-post
+func (s *accountService) UpdateUser(u *accountUser) (*http.Response, error) {
+	return s.do("POST", fmt.Sprintf("account/users/%s", u.Username), u, u)
+}
+
+// UpdateUserTeams sets the teams of the user. Only the teams are sent, as
+// the user read from the API holds its effective permissions, including the
+// ones granted by its teams, and sending them back would make them explicit.
+//
+// NS1 API docs: https://ns1.com/api/#users-user-post
+func (s *accountService) UpdateUserTeams(username string, teams []string) (*http.Response, error) {
+	return s.do("POST", fmt.Sprintf("account/users/%s", username), accountTeamIDs{TeamIDs: teams}, nil)
+}
+
+// GetAPIKey takes an API key id and returns the API key.
+//
+// NS1 API docs: https://ns1.com/api/#apikeys-id-get
+func (s *accountService) GetAPIKey(id string) (*accountAPIKey, *http.Response, error) {
+	var k accountAPIKey
+	resp, err := s.do("GET", fmt.Sprintf("account/apikeys/%s", id), nil, &k)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &k, resp, nil
+}
+
+// CreateAPIKey creates the API key, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#apikeys-put
+func (s *accountService) CreateAPIKey(k *accountAPIKey) (*http.Response, error) {
+	return s.do("PUT", "account/apikeys", k, k)
+}
+
+// UpdateAPIKey updates the API key, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#apikeys-id-post
+func (s *accountService) UpdateAPIKey(k *accountAPIKey) (*http.Response, error) {
+	return s.do("POST", fmt.Sprintf("account/apikeys/%s", k.ID), k, k)
+}
+
+// UpdateAPIKeyTeams sets the teams of the API key. Only the teams are sent,
+// as with UpdateUserTeams.
+//
+// NS1 API docs: https://ns1.com/api/#apikeys-id-post
+func (s *accountService) UpdateAPIKeyTeams(id string, teams []string) (*http.Response, error) {
+	return s.do("POST", fmt.Sprintf("account/apikeys/%s", id), accountTeamIDs{TeamIDs: teams}, nil)
+}
+
+// GetTeam takes a team id and returns the team.
+//
+// NS1 API docs: https://ns1.com/api/#teams-id-get
+func (s *accountService) GetTeam(id string) (*accountTeam, *http.Response, error) {
+	var t accountTeam
+	resp, err := s.do("GET", fmt.Sprintf("account/teams/%s", id), nil, &t)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &t, resp, nil
+}
+
+// CreateTeam creates the team, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#teams-put
+func (s *accountService) CreateTeam(t *accountTeam) (*http.Response, error) {
+	return s.do("PUT", "account/teams", t, t)
+}
+
+// UpdateTeam updates the team, and updates it with the response.
+//
+// NS1 API docs: https://ns1.com/api/#teams-id-post
+func (s *accountService) UpdateTeam(t *accountTeam) (*http.Response, error) {
+	return s.do("POST", fmt.Sprintf("account/teams/%s", t.ID), t, t)
+}
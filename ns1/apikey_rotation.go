@@ -0,0 +1,174 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// API keys are rotated by creating a successor key with the same settings,
+// which replaces the key of the resource, while the previous key stays valid
+// for an overlap window so that its consumers can move to the successor.
+// Rotations and the revocation of the previous key are due on a schedule that
+// the NS1 API does not know of, so they are reported as drift of rotation_due
+// and applied on the next update. Only attributes in the configuration or with
+// a default can drift, and this version of helper/schema has no CustomizeDiff,
+// so rotation_due has a default but refuses to be configured.
+
+// apikeyRotationSchema returns the rotation attributes.
+func apikeyRotationSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"rotation_days": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validateNonNegative,
+		},
+		"overlap_hours": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      24,
+			ValidateFunc: validateNonNegative,
+		},
+		"keepers": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+		"rotation_due": {
+			Type:         schema.TypeBool,
+			Optional:     true,
+			Default:      false,
+			ValidateFunc: validateNotConfigurable,
+		},
+		// Computed
+		"rotated_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"previous_id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"previous_key": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"previous_encrypted_key": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"previous_expires_at": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+}
+
+// addAPIKeyRotationSchema adds the rotation attributes to the given schema.
+func addAPIKeyRotationSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for k, v := range apikeyRotationSchema() {
+		s[k] = v
+	}
+	return s
+}
+
+func validateNonNegative(v interface{}, k string) (ws []string, es []error) {
+	if v.(int) < 0 {
+		es = append(es, fmt.Errorf("%q must not be negative, got: %d", k, v.(int)))
+	}
+	return
+}
+
+// validateNotConfigurable rejects any value in the configuration, for
+// attributes that are set by the provider only.
+func validateNotConfigurable(v interface{}, k string) (ws []string, es []error) {
+	es = append(es, fmt.Errorf("%q is set by the provider and cannot be configured", k))
+	return
+}
+
+// apikeyRotation returns whether the key is due to be rotated, because it is
+// older than rotation_days, and whether the previous key is due to be
+// revoked, because its overlap window has passed.
+func apikeyRotation(d *schema.ResourceData, now time.Time) (rotate, revoke bool, err error) {
+	if days := d.Get("rotation_days").(int); days > 0 {
+		if v, ok := d.GetOk("rotated_at"); ok {
+			rotatedAt, err := time.Parse(time.RFC3339, v.(string))
+			if err != nil {
+				return false, false, err
+			}
+			rotate = !now.Before(rotatedAt.AddDate(0, 0, days))
+		}
+	}
+	if _, ok := d.GetOk("previous_id"); ok {
+		expiresAt, err := time.Parse(time.RFC3339, d.Get("previous_expires_at").(string))
+		if err != nil {
+			return false, false, err
+		}
+		revoke = !now.Before(expiresAt)
+	}
+	return rotate, revoke, nil
+}
+
+// apikeyRotationToResourceData sets rotation_due, so that the key is updated
+// on the next apply when a rotation or revocation is due. Keys created before
+// rotation was supported, and imported keys, have no rotated_at, and the API
+// does not return when a key was created, so their age is counted from the
+// first time they are read.
+func apikeyRotationToResourceData(d *schema.ResourceData, now time.Time) error {
+	if _, ok := d.GetOk("rotated_at"); !ok {
+		d.Set("rotated_at", now.UTC().Format(time.RFC3339))
+	}
+	rotate, revoke, err := apikeyRotation(d, now)
+	if err != nil {
+		return err
+	}
+	return d.Set("rotation_due", rotate || revoke)
+}
+
+// revokePreviousAPIKey deletes the previous key, if any.
+func revokePreviousAPIKey(client *ns1.Client, d *schema.ResourceData) error {
+	id, ok := d.GetOk("previous_id")
+	if !ok {
+		return nil
+	}
+	log.Printf("[INFO] Revoking previous API key %s of %s", id, d.Id())
+	if _, err := client.APIKeys.Delete(id.(string)); err != nil {
+		return err
+	}
+	d.Set("previous_id", "")
+	d.Set("previous_key", "")
+	d.Set("previous_encrypted_key", "")
+	d.Set("previous_expires_at", "")
+	return nil
+}
+
+// rotateAPIKey creates a successor of the key with the settings of k, and
+// keeps the current key as the previous key for overlap_hours. A previous
+// key that is still in its overlap window is revoked first.
+func rotateAPIKey(client *ns1.Client, d *schema.ResourceData, k *accountAPIKey) error {
+	if err := revokePreviousAPIKey(client, d); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	successor := *k
+	successor.ID = ""
+	successor.Key = ""
+	if _, err := newAccountService(client).CreateAPIKey(&successor); err != nil {
+		return err
+	}
+	log.Printf("[INFO] Rotated API key %s to %s", d.Id(), successor.ID)
+
+	overlap := time.Duration(d.Get("overlap_hours").(int)) * time.Hour
+	d.Set("previous_id", d.Id())
+	d.Set("previous_key", d.Get("key"))
+	d.Set("previous_encrypted_key", d.Get("encrypted_key"))
+	d.Set("previous_expires_at", now.Add(overlap).UTC().Format(time.RFC3339))
+	d.Set("rotated_at", now.UTC().Format(time.RFC3339))
+	*k = successor
+	return nil
+}
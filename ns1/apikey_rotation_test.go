@@ -0,0 +1,68 @@
+package ns1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAPIKeyRotation_withoutRotatedAt(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, apikeyResource().Schema, map[string]interface{}{
+		"name":          "existing key",
+		"rotation_days": 30,
+	})
+	d.SetId("existing")
+
+	now := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	if err := apikeyRotationToResourceData(d, now); err != nil {
+		t.Fatalf("bad: %s", err)
+	}
+	if got := d.Get("rotated_at").(string); got != "2018-01-01T00:00:00Z" {
+		t.Fatalf("bad: rotated_at: got: %#v", got)
+	}
+	if d.Get("rotation_due").(bool) {
+		t.Fatal("bad: rotation due right away")
+	}
+
+	cases := map[string]struct {
+		Now    time.Time
+		Rotate bool
+	}{
+		"before": {now.AddDate(0, 0, 29), false},
+		"due":    {now.AddDate(0, 0, 30), true},
+	}
+	for tn, tc := range cases {
+		rotate, revoke, err := apikeyRotation(d, tc.Now)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %s", tn, err)
+		}
+		if rotate != tc.Rotate || revoke {
+			t.Fatalf("bad: %s: rotate: %t, revoke: %t", tn, rotate, revoke)
+		}
+	}
+}
+
+func TestAPIKeyRotation_rotationDueNotConfigurable(t *testing.T) {
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Err    bool
+	}{
+		"unset": {map[string]interface{}{"name": "key"}, false},
+		"false": {map[string]interface{}{"name": "key", "rotation_due": false}, true},
+		"true":  {map[string]interface{}{"name": "key", "rotation_due": true}, true},
+	}
+
+	for tn, tc := range cases {
+		raw, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %s", tn, err)
+		}
+		_, es := apikeyResource().Validate(terraform.NewResourceConfig(raw))
+		if (len(es) > 0) != tc.Err {
+			t.Fatalf("bad: %s, errors: %v", tn, es)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func dataFeedDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"source_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Computed
+			"config": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"data": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"destinations": dataFeedDestinationsSchema(),
+		},
+		Read: DataFeedDataSourceRead,
+	}
+}
+
+// DataFeedDataSourceRead looks up a datafeed by name within a datasource
+func DataFeedDataSourceRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	sourceID := d.Get("source_id").(string)
+	name := d.Get("name").(string)
+
+	feeds, _, err := client.DataFeeds.List(sourceID)
+	if err != nil {
+		return err
+	}
+	var id string
+	for _, f := range feeds {
+		if f.Name != name {
+			continue
+		}
+		if id != "" {
+			return fmt.Errorf("more than one feed named %q in datasource %s", name, sourceID)
+		}
+		id = f.ID
+	}
+	if id == "" {
+		return fmt.Errorf("no feed named %q in datasource %s", name, sourceID)
+	}
+
+	f, _, err := newDataFeedService(client).Get(sourceID, id)
+	if err != nil {
+		return err
+	}
+	dataFeedToResourceData(d, &f.Feed)
+	return dataFeedStateToResourceData(d, f)
+}
@@ -0,0 +1,74 @@
+package ns1
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceDataFeed_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDataFeedBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.ns1_datafeed.it", "id",
+						"ns1_datafeed.foobar", "id",
+					),
+					resource.TestCheckResourceAttr("data.ns1_datafeed.it", "config.label", "exampledc2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceDataFeed_missing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataSourceDataFeedMissing,
+				ExpectError: regexp.MustCompile(`no feed named "missing" in datasource`),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDataFeedBasic = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = "${ns1_datasource.api.id}"
+  config {
+    label = "exampledc2"
+  }
+}
+
+data "ns1_datafeed" "it" {
+  source_id = "${ns1_datasource.api.id}"
+  name      = "${ns1_datafeed.foobar.name}"
+}
+`
+
+const testAccDataSourceDataFeedMissing = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+data "ns1_datafeed" "it" {
+  source_id = "${ns1_datasource.api.id}"
+  name      = "missing"
+}
+`
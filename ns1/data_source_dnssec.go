@@ -0,0 +1,160 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func dnssecDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Computed
+			"keys": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dnskey": dnskeySchema(),
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"delegation": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"dnskey": dnskeySchema(),
+						"ds": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"key_tag": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"algorithm": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"digest_type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"digest": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: DNSSECRead,
+	}
+}
+
+func dnskeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"flags": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"protocol": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"algorithm": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"public_key": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
+		},
+	}
+}
+
+func dnskeysToList(keys []*dnssecKey) []map[string]interface{} {
+	l := make([]map[string]interface{}, len(keys))
+	for i, k := range keys {
+		l[i] = map[string]interface{}{
+			"flags":      k.Flags,
+			"protocol":   k.Protocol,
+			"algorithm":  k.Algorithm,
+			"public_key": k.PublicKey,
+		}
+	}
+	return l
+}
+
+func dnssecToResourceData(d *schema.ResourceData, z *zoneDNSSEC) error {
+	d.SetId(z.Zone)
+	if z.Keys != nil {
+		keys := map[string]interface{}{
+			"dnskey": dnskeysToList(z.Keys.DNSKey),
+			"ttl":    z.Keys.TTL,
+		}
+		if err := d.Set("keys", []map[string]interface{}{keys}); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting keys for: %s, error: %#v", z.Zone, err)
+		}
+	}
+	if z.Delegation != nil {
+		ds := make([]map[string]interface{}, len(z.Delegation.DS))
+		for i, r := range z.Delegation.DS {
+			ds[i] = map[string]interface{}{
+				"key_tag":     r.KeyTag,
+				"algorithm":   r.Algorithm,
+				"digest_type": r.DigestType,
+				"digest":      r.Digest,
+			}
+		}
+		delegation := map[string]interface{}{
+			"dnskey": dnskeysToList(z.Delegation.DNSKey),
+			"ds":     ds,
+			"ttl":    z.Delegation.TTL,
+		}
+		if err := d.Set("delegation", []map[string]interface{}{delegation}); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting delegation for: %s, error: %#v", z.Zone, err)
+		}
+	}
+	return nil
+}
+
+// DNSSECRead reads the DNSSEC keys and delegation records of a zone from ns1
+func DNSSECRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	zone := d.Get("zone").(string)
+	z, _, err := newDNSSECService(client).Get(zone)
+	if err != nil {
+		return err
+	}
+	if z.Zone == "" {
+		z.Zone = zone
+	}
+	return dnssecToResourceData(d, z)
+}
@@ -0,0 +1,38 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceDNSSEC_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDNSSECBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_zone.it", "dnssec", "true"),
+					resource.TestCheckResourceAttr("data.ns1_dnssec.it", "zone", "terraform-dnssec-test.io"),
+					resource.TestCheckResourceAttrSet("data.ns1_dnssec.it", "keys.0.dnskey.0.public_key"),
+					resource.TestCheckResourceAttrSet("data.ns1_dnssec.it", "delegation.0.ds.0.key_tag"),
+					resource.TestCheckResourceAttrSet("data.ns1_dnssec.it", "delegation.0.ds.0.digest"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDNSSECBasic = `
+resource "ns1_zone" "it" {
+  zone   = "terraform-dnssec-test.io"
+  dnssec = true
+}
+
+data "ns1_dnssec" "it" {
+  zone = "${ns1_zone.it.zone}"
+}
+`
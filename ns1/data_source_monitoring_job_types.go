@@ -0,0 +1,134 @@
+package ns1
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func monitoringJobTypesDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Computed
+			"names": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"job_types": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"shortdesc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"desc": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"config_schema": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"results": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"name": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"type": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"shortdesc": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"desc": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"comparators": {
+										Type:     schema.TypeList,
+										Computed: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"metric": {
+										Type:     schema.TypeBool,
+										Computed: true,
+									},
+									"validator": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Read: MonitoringJobTypesRead,
+	}
+}
+
+// MonitoringJobTypesRead reads the available monitoring job types from ns1
+func MonitoringJobTypesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	jobTypes, _, err := newMonitoringService(client).JobTypes()
+	if err != nil {
+		return err
+	}
+
+	names := jobTypeNames(jobTypes)
+	jtl := make([]map[string]interface{}, len(names))
+	for i, name := range names {
+		jt := jobTypes[name]
+		configSchema, err := json.Marshal(jt.Config)
+		if err != nil {
+			return err
+		}
+		resultNames := jt.resultNames()
+		results := make([]map[string]interface{}, len(resultNames))
+		for j, resultName := range resultNames {
+			r := jt.Results[resultName]
+			results[j] = map[string]interface{}{
+				"name":        resultName,
+				"type":        r.Type,
+				"shortdesc":   r.ShortDesc,
+				"desc":        r.Desc,
+				"comparators": r.Comparators,
+				"metric":      r.Metric,
+				"validator":   r.Validator,
+			}
+		}
+		jtl[i] = map[string]interface{}{
+			"name":          name,
+			"shortdesc":     jt.ShortDesc,
+			"desc":          jt.Desc,
+			"config_schema": string(configSchema),
+			"results":       results,
+		}
+	}
+	d.Set("names", names)
+	if err := d.Set("job_types", jtl); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting monitoring job types, error: %#v", err)
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(names, ","))))
+	return nil
+}
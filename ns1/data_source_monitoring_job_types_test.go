@@ -0,0 +1,29 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceMonitoringJobTypes_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMonitoringJobTypesBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_job_types.all", "names.#"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_job_types.all", "job_types.0.name"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_job_types.all", "job_types.0.config_schema"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_job_types.all", "job_types.0.results.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceMonitoringJobTypesBasic = `
+data "ns1_monitoring_job_types" "all" {}
+`
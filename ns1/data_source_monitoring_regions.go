@@ -0,0 +1,73 @@
+package ns1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func monitoringRegionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Computed
+			"codes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"regions": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"code": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subnets": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+		Read: MonitoringRegionsRead,
+	}
+}
+
+// MonitoringRegionsRead reads the available monitoring regions from ns1
+func MonitoringRegionsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	rl, _, err := newMonitoringService(client).Regions()
+	if err != nil {
+		return err
+	}
+
+	codes := make([]string, len(rl))
+	regions := make([]map[string]interface{}, len(rl))
+	for i, r := range rl {
+		codes[i] = r.Code
+		regions[i] = map[string]interface{}{
+			"code":    r.Code,
+			"name":    r.Name,
+			"subnets": r.Subnets,
+		}
+	}
+	d.Set("codes", codes)
+	if err := d.Set("regions", regions); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting monitoring regions, error: %#v", err)
+	}
+	d.SetId(strconv.Itoa(hashcode.String(strings.Join(codes, ","))))
+	return nil
+}
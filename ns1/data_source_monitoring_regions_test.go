@@ -0,0 +1,28 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceMonitoringRegions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMonitoringRegionsBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_regions.all", "codes.#"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_regions.all", "regions.0.code"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoring_regions.all", "regions.0.name"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceMonitoringRegionsBasic = `
+data "ns1_monitoring_regions" "all" {}
+`
@@ -0,0 +1,201 @@
+package ns1
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func monitoringJobHistoryDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"job_id": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			// Optional
+			"start": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+			},
+			"end": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339,
+			},
+			"regions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"limit": {
+				Type:     schema.TypeInt,
+				Optional: true,
+			},
+			// Computed
+			"history": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"status": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"since": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"until": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"uptime": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"region": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"percent": {
+							Type:     schema.TypeFloat,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+		Read: MonitoringJobHistoryRead,
+	}
+}
+
+func validateRFC3339(v interface{}, k string) (ws []string, es []error) {
+	if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be a RFC3339 timestamp: %s", k, err))
+	}
+	return
+}
+
+// monitoringJobUptime returns the percentage of the time covered by the
+// status logs of each region, clipped to [start, end], that the job was up.
+func monitoringJobUptime(logs []*monitor.StatusLog, start, end int) map[string]float64 {
+	up := make(map[string]int)
+	total := make(map[string]int)
+	for _, l := range logs {
+		since, until := l.Since, l.Until
+		// A log without an end is the current status.
+		if until == 0 || until > end {
+			until = end
+		}
+		if since < start {
+			since = start
+		}
+		if until <= since {
+			continue
+		}
+		total[l.Region] += until - since
+		if l.Status == "up" {
+			up[l.Region] += until - since
+		}
+	}
+	uptime := make(map[string]float64)
+	for region, t := range total {
+		uptime[region] = 100 * float64(up[region]) / float64(t)
+	}
+	return uptime
+}
+
+// MonitoringJobHistoryRead reads the status history of a monitoring job from ns1
+func MonitoringJobHistoryRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	jobID := d.Get("job_id").(string)
+
+	var opts []func(*url.Values)
+	start := time.Unix(0, 0)
+	if v, ok := d.GetOk("start"); ok {
+		start, _ = time.Parse(time.RFC3339, v.(string))
+		opts = append(opts, ns1.SetTimeParam("start", start))
+	}
+	end := time.Now()
+	if v, ok := d.GetOk("end"); ok {
+		end, _ = time.Parse(time.RFC3339, v.(string))
+		opts = append(opts, ns1.SetTimeParam("end", end))
+	}
+	if !end.After(start) {
+		return fmt.Errorf("end %s must be after start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+	if v, ok := d.GetOk("limit"); ok {
+		opts = append(opts, ns1.SetIntParam("limit", v.(int)))
+	}
+
+	logs, _, err := client.Jobs.History(jobID, opts...)
+	if err != nil {
+		return err
+	}
+
+	if v, ok := d.GetOk("regions"); ok {
+		regions := make(map[string]bool)
+		for _, r := range v.([]interface{}) {
+			regions[r.(string)] = true
+		}
+		filtered := make([]*monitor.StatusLog, 0, len(logs))
+		for _, l := range logs {
+			if regions[l.Region] {
+				filtered = append(filtered, l)
+			}
+		}
+		logs = filtered
+	}
+
+	history := make([]map[string]interface{}, len(logs))
+	for i, l := range logs {
+		history[i] = map[string]interface{}{
+			"region": l.Region,
+			"status": l.Status,
+			"since":  l.Since,
+			"until":  l.Until,
+		}
+	}
+	if err := d.Set("history", history); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting history for: %s, error: %#v", jobID, err)
+	}
+
+	uptimeByRegion := monitoringJobUptime(logs, int(start.Unix()), int(end.Unix()))
+	regions := make([]string, 0, len(uptimeByRegion))
+	for region := range uptimeByRegion {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+	uptime := make([]map[string]interface{}, len(regions))
+	for i, region := range regions {
+		uptime[i] = map[string]interface{}{
+			"region":  region,
+			"percent": uptimeByRegion[region],
+		}
+	}
+	if err := d.Set("uptime", uptime); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting uptime for: %s, error: %#v", jobID, err)
+	}
+
+	// The id is derived from the configuration rather than the window, whose
+	// end is the current time if not set.
+	d.SetId(fmt.Sprintf("%s/%s/%s", jobID, d.Get("start"), d.Get("end")))
+	return nil
+}
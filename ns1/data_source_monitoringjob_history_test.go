@@ -0,0 +1,112 @@
+package ns1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestAccDataSourceMonitoringJobHistory_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceMonitoringJobHistoryBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.ns1_monitoringjob_history.it", "job_id",
+						"ns1_monitoringjob.it", "id",
+					),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoringjob_history.it", "history.#"),
+					resource.TestCheckResourceAttrSet("data.ns1_monitoringjob_history.it", "uptime.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestMonitoringJobUptime(t *testing.T) {
+	cases := map[string]struct {
+		Logs     []*monitor.StatusLog
+		Expected map[string]float64
+	}{
+		"empty": {
+			Logs:     []*monitor.StatusLog{},
+			Expected: map[string]float64{},
+		},
+		"within": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "up", Since: 1000, Until: 1600},
+				{Region: "lga", Status: "down", Since: 1600, Until: 2000},
+			},
+			Expected: map[string]float64{"lga": 60},
+		},
+		"overlapping_start": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "down", Since: 0, Until: 1500},
+				{Region: "lga", Status: "up", Since: 1500, Until: 2000},
+			},
+			Expected: map[string]float64{"lga": 50},
+		},
+		"overlapping_end": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "up", Since: 1000, Until: 1250},
+				{Region: "lga", Status: "down", Since: 1250, Until: 5000},
+			},
+			Expected: map[string]float64{"lga": 25},
+		},
+		"open_ended": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "down", Since: 1000, Until: 1200},
+				{Region: "lga", Status: "up", Since: 1200},
+			},
+			Expected: map[string]float64{"lga": 80},
+		},
+		"outside": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "up", Since: 0, Until: 500},
+				{Region: "sjc", Status: "up", Since: 2500, Until: 3000},
+			},
+			Expected: map[string]float64{},
+		},
+		"regions": {
+			Logs: []*monitor.StatusLog{
+				{Region: "lga", Status: "up", Since: 1000, Until: 2000},
+				{Region: "sjc", Status: "down", Since: 1000, Until: 2000},
+			},
+			Expected: map[string]float64{"lga": 100, "sjc": 0},
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := monitoringJobUptime(tc.Logs, 1000, 2000); !reflect.DeepEqual(got, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, got)
+		}
+	}
+}
+
+const testAccDataSourceMonitoringJobHistoryBasic = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+
+data "ns1_monitoringjob_history" "it" {
+  job_id  = "${ns1_monitoringjob.it.id}"
+  start   = "2018-01-01T00:00:00Z"
+  regions = ["lga"]
+}
+`
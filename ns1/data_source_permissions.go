@@ -0,0 +1,33 @@
+package ns1
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func permissionsDataSource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"role": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: roleStringEnum.ValidateFunc,
+			},
+			// Computed
+			"permissions": permissionsSchema(true),
+		},
+		Read: PermissionsRead,
+	}
+}
+
+// PermissionsRead renders the permissions of a built-in role
+func PermissionsRead(d *schema.ResourceData, meta interface{}) error {
+	role := d.Get("role").(string)
+	if err := d.Set("permissions", permissionsToList(permissionRoles[role])); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting permissions for: %s, error: %#v", role, err)
+	}
+	d.SetId(role)
+	return nil
+}
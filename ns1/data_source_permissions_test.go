@@ -0,0 +1,33 @@
+package ns1
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourcePermissions_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePermissionsBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "id", "read_only"),
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "permissions.0.dns.0.view_zones", "true"),
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "permissions.0.dns.0.manage_zones", "false"),
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "permissions.0.monitoring.0.view_jobs", "true"),
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "permissions.0.account.0.manage_users", "false"),
+					resource.TestCheckResourceAttr("data.ns1_permissions.ro", "permissions.0.ipam.0.view_ipam", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePermissionsBasic = `
+data "ns1_permissions" "ro" {
+  role = "read_only"
+}
+`
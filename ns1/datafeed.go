@@ -0,0 +1,52 @@
+package ns1
+
+import (
+	"fmt"
+	"net/http"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// The vendored ns1-go client drops the destinations of data feeds, so feeds
+// are read here on top of its request helpers.
+
+// dataFeedService handles the 'data/feeds' endpoint.
+type dataFeedService struct {
+	client *ns1.Client
+}
+
+func newDataFeedService(client *ns1.Client) *dataFeedService {
+	return &dataFeedService{client: client}
+}
+
+// dataFeed wraps an NS1 /data/feeds resource along with its destinations.
+type dataFeed struct {
+	data.Feed
+
+	// Destinations are the records, regions and answers whose meta the feed
+	// is connected to.
+	Destinations []*data.Destination `json:"destinations"`
+}
+
+// Get takes a data source ID and a data feed ID and returns the data feed
+// along with its destinations.
+//
+// NS1 API docs: https://ns1.com/api/#feeds-feed-get
+func (s *dataFeedService) Get(sourceID string, feedID string) (*dataFeed, *http.Response, error) {
+	path := fmt.Sprintf("data/feeds/%s/%s", sourceID, feedID)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var df dataFeed
+	resp, err := s.client.Do(req, &df)
+	if err != nil {
+		return nil, resp, err
+	}
+	df.SourceID = sourceID
+
+	return &df, resp, nil
+}
@@ -0,0 +1,193 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// dataSourceTypesWithoutConfig are the source types of NS1 itself, which
+// take no configuration.
+var dataSourceTypesWithoutConfig = []string{
+	"nsone_v1",
+	"nsone_monitoring",
+}
+
+// dataSourceConfigSchemas holds the typed configuration block for each third
+// party source type, keyed by source type. The block attributes map one to
+// one onto the keys of the sources' config. Sources of other types are
+// configured with the untyped config map.
+var dataSourceConfigSchemas = map[string]map[string]*schema.Schema{
+	"a10": {
+		"host": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+	},
+	"aws": {
+		"access_key_id": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"secret_access_key": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"region": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+	},
+	"datadog": {
+		"api_key": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"application_key": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+	},
+	"pingdom": {
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"app_key": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+	},
+}
+
+// dataSourceConfigKey returns the name of the typed configuration block for
+// the given source type.
+func dataSourceConfigKey(sourceType string) string {
+	return sourceType + "_config"
+}
+
+// dataSourceConfigKeys returns the names of all typed configuration blocks.
+func dataSourceConfigKeys() []string {
+	keys := make([]string, 0, len(dataSourceConfigSchemas))
+	for sourceType := range dataSourceConfigSchemas {
+		keys = append(keys, dataSourceConfigKey(sourceType))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addDataSourceConfigSchema adds a typed configuration block for each source
+// type to the given data source schema.
+func addDataSourceConfigSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for sourceType, fields := range dataSourceConfigSchemas {
+		s[dataSourceConfigKey(sourceType)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+	return s
+}
+
+// dataSourceConfigBlock returns the typed configuration block of a data
+// source, if set.
+func dataSourceConfigBlock(d *schema.ResourceData, key string) (map[string]interface{}, bool) {
+	v, ok := d.Get(key).([]interface{})
+	if !ok || len(v) == 0 || v[0] == nil {
+		return nil, false
+	}
+	return v[0].(map[string]interface{}), true
+}
+
+// resourceDataToDataSourceConfig builds the config of a data source from
+// whichever of the typed configuration block or the untyped config map is
+// set, and checks that the config of known source types has all required
+// keys.
+func resourceDataToDataSourceConfig(d *schema.ResourceData, sourceType string) (data.Config, error) {
+	for _, key := range dataSourceConfigKeys() {
+		if _, ok := dataSourceConfigBlock(d, key); ok && key != dataSourceConfigKey(sourceType) {
+			return nil, fmt.Errorf("%s cannot be used with sourcetype %q", key, sourceType)
+		}
+	}
+
+	rawConfig := d.Get("config").(map[string]interface{})
+	if len(rawConfig) > 0 && stringInSlice(sourceType, dataSourceTypesWithoutConfig) {
+		return nil, fmt.Errorf("config cannot be used with sourcetype %q", sourceType)
+	}
+	block, typed := dataSourceConfigBlock(d, dataSourceConfigKey(sourceType))
+	if typed && len(rawConfig) > 0 {
+		return nil, fmt.Errorf("only one of config or %s can be set", dataSourceConfigKey(sourceType))
+	}
+	if !typed {
+		block = rawConfig
+	}
+
+	config := make(data.Config)
+	for k, v := range block {
+		if s, ok := v.(string); !ok || s != "" {
+			config[k] = v
+		}
+	}
+	fields, known := dataSourceConfigSchemas[sourceType]
+	if known && len(config) == 0 {
+		return nil, fmt.Errorf("%s must be set for sourcetype %q", dataSourceConfigKey(sourceType), sourceType)
+	}
+	for k, s := range fields {
+		if _, ok := config[k]; s.Required && !ok {
+			return nil, fmt.Errorf("%s source is missing required config %q", sourceType, k)
+		}
+	}
+	return config, nil
+}
+
+// dataSourceConfigToResourceData sets the config of a data source on the
+// typed configuration block, unless the data source in the state uses the
+// untyped config map or the source type is not known.
+func dataSourceConfigToResourceData(d *schema.ResourceData, s *data.Source) error {
+	config := make(map[string]interface{})
+	for k, v := range s.Config {
+		if v != nil {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+
+	fields, typed := dataSourceConfigSchemas[s.Type]
+	if rawConfig := d.Get("config").(map[string]interface{}); len(rawConfig) > 0 {
+		typed = false
+	}
+	if !typed {
+		return d.Set("config", config)
+	}
+	block := make(map[string]interface{})
+	for k := range fields {
+		if v, ok := config[k]; ok {
+			block[k] = v
+		}
+	}
+	d.Set("config", map[string]interface{}{})
+	return d.Set(dataSourceConfigKey(s.Type), []map[string]interface{}{block})
+}
@@ -0,0 +1,151 @@
+package ns1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+// The vendored ns1-go client has no support for DNSSEC, so the endpoints used
+// by the provider are implemented here on top of its request helpers.
+
+// dnssecService handles the 'zones/ZONE/dnssec' endpoint and the zones'
+// dnssec flag.
+type dnssecService struct {
+	client *ns1.Client
+}
+
+func newDNSSECService(client *ns1.Client) *dnssecService {
+	return &dnssecService{client: client}
+}
+
+// zoneDNSSEC wraps an NS1 /zones/ZONE/dnssec resource.
+type zoneDNSSEC struct {
+	Zone       string            `json:"zone,omitempty"`
+	Keys       *dnssecKeys       `json:"keys,omitempty"`
+	Delegation *dnssecDelegation `json:"delegation,omitempty"`
+}
+
+// dnssecKeys holds the DNSKEY records the zone is signed with.
+type dnssecKeys struct {
+	DNSKey []*dnssecKey `json:"dnskey,omitempty"`
+	TTL    int          `json:"ttl,omitempty"`
+}
+
+// dnssecDelegation holds the records to publish in the parent zone.
+type dnssecDelegation struct {
+	DNSKey []*dnssecKey `json:"dnskey,omitempty"`
+	DS     []*dnssecDS  `json:"ds,omitempty"`
+	TTL    int          `json:"ttl,omitempty"`
+}
+
+// dnssecKey is the RDATA of a DNSKEY record, which the API returns as an
+// array of [flags, protocol, algorithm, public key].
+type dnssecKey struct {
+	Flags     string
+	Protocol  string
+	Algorithm string
+	PublicKey string
+}
+
+func (k *dnssecKey) UnmarshalJSON(b []byte) error {
+	return unmarshalRdata(b, &k.Flags, &k.Protocol, &k.Algorithm, &k.PublicKey)
+}
+
+// dnssecDS is the RDATA of a DS record, which the API returns as an array of
+// [key tag, algorithm, digest type, digest].
+type dnssecDS struct {
+	KeyTag     string
+	Algorithm  string
+	DigestType string
+	Digest     string
+}
+
+func (ds *dnssecDS) UnmarshalJSON(b []byte) error {
+	return unmarshalRdata(b, &ds.KeyTag, &ds.Algorithm, &ds.DigestType, &ds.Digest)
+}
+
+// unmarshalRdata decodes a JSON array of strings and numbers into fields.
+func unmarshalRdata(b []byte, fields ...*string) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var raw []interface{}
+	if err := dec.Decode(&raw); err != nil {
+		return err
+	}
+	if len(raw) != len(fields) {
+		return fmt.Errorf("expected %d rdata fields, got %d", len(fields), len(raw))
+	}
+	for i, v := range raw {
+		*fields[i] = fmt.Sprint(v)
+	}
+	return nil
+}
+
+// Get takes a zone and returns its DNSSEC keys and delegation records.
+func (s *dnssecService) Get(zone string) (*zoneDNSSEC, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s/dnssec", zone)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var d zoneDNSSEC
+	resp, err := s.client.Do(req, &d)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return &d, resp, nil
+}
+
+// dnssecZone extends dns.Zone with the zone's dnssec flag, which the
+// vendored model drops.
+type dnssecZone struct {
+	dns.Zone
+	DNSSEC bool `json:"dnssec"`
+}
+
+// GetZone takes a zone and returns it along with whether DNSSEC is enabled,
+// in a single request.
+//
+// NS1 API docs: https://ns1.com/api/#zones-zone-get
+func (s *dnssecService) GetZone(zone string) (*dnssecZone, *http.Response, error) {
+	path := fmt.Sprintf("zones/%s", zone)
+
+	req, err := s.client.NewRequest("GET", path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var z dnssecZone
+	resp, err := s.client.Do(req, &z)
+	if err != nil {
+		if e, ok := err.(*ns1.Error); ok && e.Message == "zone not found" {
+			return nil, resp, ns1.ErrZoneMissing
+		}
+		return nil, resp, err
+	}
+
+	return &z, resp, nil
+}
+
+// SetEnabled enables or disables DNSSEC for the zone.
+//
+// NS1 API docs: https://ns1.com/api/#zones-post
+func (s *dnssecService) SetEnabled(zone string, enabled bool) (*http.Response, error) {
+	path := fmt.Sprintf("zones/%s", zone)
+
+	body := map[string]interface{}{"dnssec": enabled}
+	req, err := s.client.NewRequest("POST", path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.Do(req, nil)
+}
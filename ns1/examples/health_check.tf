@@ -0,0 +1,20 @@
+resource "ns1_health_check" "it" {
+  #required
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    port = 443
+    host = "1.2.3.4"
+  }
+
+  #optional
+  feed_name = "terraform test feed"
+
+  wait_for_status {
+    status = "up"
+  }
+}
@@ -6,8 +6,8 @@ resource "ns1_monitoringjob" "it" {
   regions   = ["lga"]
   frequency = 60
 
-  config = {
-    ssl = "1",
+  tcp_config {
+    ssl  = true
     send = "HEAD / HTTP/1.0\r\n\r\n"
     port = 443
     host = "1.2.3.4"
@@ -9,23 +9,55 @@
 #   ns1_team
 #   ns1_user
 
-# permissions values and types:
-#   dns_view_zones: boolean - allows the requestor to view zones
-#   dns_manage_zones: boolean - allows the requestor to edit/manage zones
-#   dns_zones_allow_by_default: boolean
-#   dns_zones_deny: list of strings - explicitly deny these zones for this user/team/key
-#   dns_zones_allow: list of strings - explicitly allow these zones for this user/team/key
-#   data_push_to_datafeeds: boolean - allows the requestor to push to datafeeds
-#   data_manage_datasources: boolean - allows the requestor to manage datasources
-#   data_manage_datafeeds: boolean - allows the requestor to manage datafeeds
-#   account_manage_users: boolean - allows the requstor to manage users
-#   account_manage_payment_methods: boolean - allows the requestor to manage payment methods
-#   account_manage_plan: boolean - allows the requestor to manage the account payment plan
-#   account_manage_teams: boolean - allows the requestor to manage teams
-#   account_manage_apikeys: boolean - allows the requestor to manage apikeys
-#   account_manage_account_settings: boolean - allows the requestor to manage account settings
-#   account_view_activity_log: boolean - allows the requestor to view the activity log
-#   account_view_invoices: boolean - allows the requestor to view account invoices
-#   monitoring_manage_lists: boolean - allows the requestor to manage monitoring lists
-#   monitoring_manage_jobs: boolean - allows the requestor to manage monitoring jobs
-#   monitoring_view_jobs: boolean - allows the requestor to view monitoring jobs
\ No newline at end of file
+# role: string - a built-in preset of permissions, one of read_only, dns_admin,
+#   monitoring_admin or full_admin, granted in addition to the permissions below;
+#   the ns1_permissions data source renders the permissions of a preset
+
+# permissions are set in a permissions block, with a nested block for each
+# family of permissions:
+#
+# permissions {
+#   dns {
+#     view_zones: boolean - allows the requestor to view zones
+#     manage_zones: boolean - allows the requestor to edit/manage zones
+#     zones_allow_by_default: boolean
+#     zones_deny: list of strings - explicitly deny these zones for this user/team/key
+#     zones_allow: list of strings - explicitly allow these zones for this user/team/key
+#     records_deny: list of records - explicitly deny these records for this user/team/key
+#     records_allow: list of records - explicitly allow these records for this user/team/key
+#       each record has a domain, zone and type, and subdomains: boolean
+#   }
+#   data {
+#     push_to_datafeeds: boolean - allows the requestor to push to datafeeds
+#     manage_datasources: boolean - allows the requestor to manage datasources
+#     manage_datafeeds: boolean - allows the requestor to manage datafeeds
+#   }
+#   account {
+#     manage_users: boolean - allows the requstor to manage users
+#     manage_payment_methods: boolean - allows the requestor to manage payment methods
+#     manage_plan: boolean - allows the requestor to manage the account payment plan
+#     manage_teams: boolean - allows the requestor to manage teams
+#     manage_apikeys: boolean - allows the requestor to manage apikeys
+#     manage_account_settings: boolean - allows the requestor to manage account settings
+#     view_activity_log: boolean - allows the requestor to view the activity log
+#     view_invoices: boolean - allows the requestor to view account invoices
+#     manage_ip_whitelist: boolean - allows the requestor to manage ip whitelists
+#   }
+#   monitoring {
+#     manage_lists: boolean - allows the requestor to manage monitoring lists
+#     manage_jobs: boolean - allows the requestor to manage monitoring jobs
+#     view_jobs: boolean - allows the requestor to view monitoring jobs
+#   }
+#   security {
+#     manage_global_2fa: boolean - allows the requestor to manage two factor authentication
+#     manage_active_directory: boolean - allows the requestor to manage active directory
+#   }
+#   dhcp {
+#     manage_dhcp: boolean - allows the requestor to manage dhcp
+#     view_dhcp: boolean - allows the requestor to view dhcp
+#   }
+#   ipam {
+#     manage_ipam: boolean - allows the requestor to manage ipam
+#     view_ipam: boolean - allows the requestor to view ipam
+#   }
+# }
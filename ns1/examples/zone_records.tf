@@ -0,0 +1,20 @@
+resource "ns1_zone_records" "it" {
+  zone = "${ns1_zone.test.zone}"
+
+  records {
+    domain  = "www.${ns1_zone.test.zone}"
+    type    = "A"
+    ttl     = 60
+    answers = ["1.2.3.4", "1.2.3.5"]
+  }
+
+  records {
+    domain  = "${ns1_zone.test.zone}"
+    type    = "MX"
+    answers = ["10 mail.${ns1_zone.test.zone}"]
+  }
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-test-zone.io"
+}
@@ -0,0 +1,71 @@
+package ns1
+
+import (
+	"fmt"
+	"net"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// ipWhitelist holds the addresses users, teams and API keys may access the
+// API from.
+type ipWhitelist struct {
+	IPWhitelist []string `json:"ip_whitelist"`
+
+	// IPWhitelistStrict restricts access to the addresses in IPWhitelist.
+	IPWhitelistStrict bool `json:"ip_whitelist_strict"`
+}
+
+func addIPWhitelistSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	s["ip_whitelist"] = &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateIPWhitelistEntry,
+		},
+		Set: schema.HashString,
+	}
+	s["ip_whitelist_strict"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+	}
+	return s
+}
+
+// validateIPWhitelistEntry checks that the entry is an IP address or a CIDR
+// block, and that CIDR blocks have no host bits set.
+func validateIPWhitelistEntry(v interface{}, k string) (ws []string, es []error) {
+	entry := v.(string)
+	if net.ParseIP(entry) != nil {
+		return
+	}
+	ip, ipNet, err := net.ParseCIDR(entry)
+	if err != nil {
+		es = append(es, fmt.Errorf("%q must be an IP address or a CIDR block, got: %s", k, entry))
+		return
+	}
+	if !ip.Equal(ipNet.IP) {
+		es = append(es, fmt.Errorf("%q must be a CIDR block without host bits, got: %s, did you mean %s?", k, entry, ipNet))
+	}
+	return
+}
+
+func ipWhitelistToResourceData(d *schema.ResourceData, w ipWhitelist) error {
+	if err := d.Set("ip_whitelist", w.IPWhitelist); err != nil {
+		return err
+	}
+	return d.Set("ip_whitelist_strict", w.IPWhitelistStrict)
+}
+
+func resourceDataToIPWhitelist(w *ipWhitelist, d *schema.ResourceData) {
+	w.IPWhitelist = make([]string, 0)
+	if v, ok := d.GetOk("ip_whitelist"); ok {
+		for _, entry := range v.(*schema.Set).List() {
+			w.IPWhitelist = append(w.IPWhitelist, entry.(string))
+		}
+		sort.Strings(w.IPWhitelist)
+	}
+	w.IPWhitelistStrict = d.Get("ip_whitelist_strict").(bool)
+}
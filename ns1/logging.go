@@ -0,0 +1,61 @@
+package ns1
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// redactAttributes returns a copy of the flattened attributes of a state that
+// can be logged, with the values of the attributes marked Sensitive in any of
+// the given schemas masked.
+func redactAttributes(attrs map[string]string, schemas ...map[string]*schema.Schema) map[string]string {
+	redacted := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		redacted[k] = v
+		for _, s := range schemas {
+			if attributeSensitive(s, strings.Split(k, ".")) {
+				redacted[k] = "<sensitive>"
+				break
+			}
+		}
+	}
+	return redacted
+}
+
+// attributeSensitive reports whether the attribute at the given flattened
+// path is marked Sensitive in the schema, or nested in a sensitive attribute.
+func attributeSensitive(s map[string]*schema.Schema, path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	field, ok := s[path[0]]
+	if !ok {
+		return false
+	}
+	if field.Sensitive {
+		return true
+	}
+	rest := path[1:]
+	for len(rest) > 0 {
+		if _, err := strconv.Atoi(rest[0]); err != nil && rest[0] != "#" && rest[0] != "%" {
+			break
+		}
+		rest = rest[1:]
+	}
+	if r, ok := field.Elem.(*schema.Resource); ok {
+		return attributeSensitive(r.Schema, rest)
+	}
+	return false
+}
+
+// permissionsResourceSchemas returns the schemas of the resources whose
+// states are migrated by migratePermissionsState.
+func permissionsResourceSchemas() []map[string]*schema.Schema {
+	return []map[string]*schema.Schema{
+		userResource().Schema,
+		teamResource().Schema,
+		apikeyResource().Schema,
+	}
+}
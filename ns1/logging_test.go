@@ -0,0 +1,57 @@
+package ns1
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedactAttributes(t *testing.T) {
+	cases := map[string]struct {
+		Attributes map[string]string
+		Expected   map[string]string
+	}{
+		"apikey": {
+			Attributes: map[string]string{
+				"name":         "example",
+				"key":          "secret",
+				"previous_key": "previous secret",
+				"previous_id":  "abc",
+			},
+			Expected: map[string]string{
+				"name":         "example",
+				"key":          "<sensitive>",
+				"previous_key": "<sensitive>",
+				"previous_id":  "abc",
+			},
+		},
+		"datasource": {
+			Attributes: map[string]string{
+				"name":                      "example",
+				"sourcetype":                "pingdom",
+				"pingdom_config.#":          "1",
+				"pingdom_config.0.username": "user",
+				"pingdom_config.0.password": "secret",
+				"pingdom_config.0.app_key":  "app secret",
+			},
+			Expected: map[string]string{
+				"name":                      "example",
+				"sourcetype":                "pingdom",
+				"pingdom_config.#":          "1",
+				"pingdom_config.0.username": "user",
+				"pingdom_config.0.password": "<sensitive>",
+				"pingdom_config.0.app_key":  "<sensitive>",
+			},
+		},
+	}
+
+	schemas := append(permissionsResourceSchemas(), dataSourceResource().Schema)
+	for tn, tc := range cases {
+		redacted := redactAttributes(tc.Attributes, schemas...)
+		if !reflect.DeepEqual(redacted, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, redacted)
+		}
+		if reflect.DeepEqual(tc.Attributes, tc.Expected) {
+			t.Fatalf("bad: %s, attributes were modified: %#v", tn, tc.Attributes)
+		}
+	}
+}
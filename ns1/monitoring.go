@@ -0,0 +1,94 @@
+package ns1
+
+import (
+	"net/http"
+	"sort"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+// The vendored ns1-go client only covers monitoring jobs and notification
+// lists, so the monitoring catalogue endpoints used by the provider are
+// implemented here on top of its request helpers.
+
+// monitoringService handles the read-only 'monitoring' catalogue endpoints.
+type monitoringService struct {
+	client *ns1.Client
+}
+
+func newMonitoringService(client *ns1.Client) *monitoringService {
+	return &monitoringService{client: client}
+}
+
+// monitoringJobType wraps a value of the NS1 /monitoring/jobtypes resource.
+type monitoringJobType struct {
+	ShortDesc string `json:"shortdesc"`
+	Desc      string `json:"desc"`
+
+	// Config is the JSON schema of the job types' config.
+	Config map[string]interface{} `json:"config"`
+
+	// Results are the metrics produced by the job type, keyed by the name
+	// that rules refer to.
+	Results map[string]*monitor.Result `json:"results"`
+}
+
+// resultNames returns the sorted names of the job types' results.
+func (jt *monitoringJobType) resultNames() []string {
+	names := make([]string, 0, len(jt.Results))
+	for name := range jt.Results {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// jobTypeNames returns the sorted names of the given job types.
+func jobTypeNames(jobTypes map[string]*monitoringJobType) []string {
+	names := make([]string, 0, len(jobTypes))
+	for name := range jobTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// monitoringRegion wraps an element of the NS1 /monitoring/regions resource.
+type monitoringRegion struct {
+	Code    string   `json:"code"`
+	Name    string   `json:"name"`
+	Subnets []string `json:"subnets"`
+}
+
+// JobTypes returns all available monitoring job types, keyed by name.
+func (s *monitoringService) JobTypes() (map[string]*monitoringJobType, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/jobtypes", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jt := map[string]*monitoringJobType{}
+	resp, err := s.client.Do(req, &jt)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return jt, resp, nil
+}
+
+// Regions returns all monitoring regions.
+func (s *monitoringService) Regions() ([]*monitoringRegion, *http.Response, error) {
+	req, err := s.client.NewRequest("GET", "monitoring/regions", nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rl := []*monitoringRegion{}
+	resp, err := s.client.Do(req, &rl)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rl, resp, nil
+}
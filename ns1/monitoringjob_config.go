@@ -0,0 +1,338 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+var httpMethodStringEnum *StringEnum = NewStringEnum([]string{
+	"GET",
+	"HEAD",
+	"POST",
+})
+
+// monitoringJobConfigSchemas holds the typed configuration block for each
+// job type, keyed by job type. The block attributes map one to one onto the
+// keys of the jobs' config, see monitor.NewHTTPConfig, monitor.NewTCPConfig,
+// monitor.NewDNSConfig and monitor.NewPINGConfig.
+var monitoringJobConfigSchemas = map[string]map[string]*schema.Schema{
+	"http": {
+		"url": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"method": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: httpMethodStringEnum.ValidateFunc,
+		},
+		"user_agent": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+		"auth": {
+			Type:      schema.TypeString,
+			Optional:  true,
+			Sensitive: true,
+		},
+		"connection_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"idle_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"headers": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+		"follow_redirect": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"tls_skip_verify": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	},
+	"tcp": {
+		"host": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"port": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+		"connection_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"response_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"send": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"ssl": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"tls_sni": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"tls_skip_verify": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+	},
+	"dns": {
+		"host": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"domain": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"port": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ValidateFunc: recordTypeStringEnum.ValidateFunc,
+		},
+		"response_timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+	},
+	"ping": {
+		"host": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"timeout": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"count": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+		"interval": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+		},
+	},
+}
+
+// monitoringJobConfigKey returns the name of the typed configuration block
+// for the given job type.
+func monitoringJobConfigKey(jobType string) string {
+	return jobType + "_config"
+}
+
+// monitoringJobConfigKeys returns the names of all typed configuration blocks.
+func monitoringJobConfigKeys() []string {
+	keys := make([]string, 0, len(monitoringJobConfigSchemas))
+	for jobType := range monitoringJobConfigSchemas {
+		keys = append(keys, monitoringJobConfigKey(jobType))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addMonitoringJobConfigSchema adds a typed configuration block for each job
+// type to the given schema. The blocks conflict with each other and with the
+// untyped config map.
+func addMonitoringJobConfigSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	keys := monitoringJobConfigKeys()
+	for jobType, fields := range monitoringJobConfigSchemas {
+		key := monitoringJobConfigKey(jobType)
+		conflicts := []string{"config"}
+		for _, k := range keys {
+			if k != key {
+				conflicts = append(conflicts, k)
+			}
+		}
+		s[key] = &schema.Schema{
+			Type:          schema.TypeList,
+			Optional:      true,
+			MaxItems:      1,
+			ConflictsWith: conflicts,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+	return s
+}
+
+// resourceDataToMonitoringJobConfig builds the jobs' config from whichever of
+// the typed configuration block or the untyped config map is set.
+func resourceDataToMonitoringJobConfig(j *monitor.Job, d *schema.ResourceData) error {
+	for _, key := range monitoringJobConfigKeys() {
+		if _, ok := d.GetOk(key); ok && key != monitoringJobConfigKey(j.Type) {
+			return fmt.Errorf("%s cannot be used with job_type %q", key, j.Type)
+		}
+	}
+
+	fields, typed := monitoringJobConfigSchemas[j.Type]
+	if v, ok := d.GetOk(monitoringJobConfigKey(j.Type)); ok && typed {
+		block := v.([]interface{})[0].(map[string]interface{})
+		config := make(monitor.Config)
+		for k, s := range fields {
+			raw, ok := block[k]
+			if !ok {
+				continue
+			}
+			switch s.Type {
+			case schema.TypeString:
+				if raw.(string) != "" {
+					config[k] = raw
+				}
+			case schema.TypeInt:
+				if raw.(int) != 0 {
+					config[k] = raw
+				}
+			case schema.TypeBool:
+				config[k] = raw
+			case schema.TypeMap:
+				if len(raw.(map[string]interface{})) > 0 {
+					config[k] = raw
+				}
+			}
+		}
+		j.Config = config
+		return nil
+	}
+
+	rawConfig, ok := d.GetOk("config")
+	if !ok {
+		if typed {
+			return fmt.Errorf("one of config or %s must be set", monitoringJobConfigKey(j.Type))
+		}
+		return fmt.Errorf("config must be set for job_type %q", j.Type)
+	}
+	config := make(map[string]interface{})
+	for k, v := range rawConfig.(map[string]interface{}) {
+		if k == "ssl" {
+			if v.(string) == "1" {
+				config[k] = true
+			}
+		} else {
+			if i, err := strconv.Atoi(v.(string)); err == nil {
+				config[k] = i
+			} else {
+				config[k] = v
+			}
+		}
+	}
+	j.Config = config
+	return nil
+}
+
+// monitoringJobConfigToResourceData sets the jobs' config on the typed
+// configuration block, unless the untyped config map is in use.
+func monitoringJobConfigToResourceData(d *schema.ResourceData, j *monitor.Job) error {
+	fields, typed := monitoringJobConfigSchemas[j.Type]
+	if _, ok := d.GetOk("config"); ok || !typed {
+		config := make(map[string]string)
+		for k, v := range j.Config {
+			if k == "ssl" {
+				if configBool(v) {
+					config[k] = "1"
+				} else {
+					config[k] = "0"
+				}
+			} else {
+				switch t := v.(type) {
+				case string:
+					config[k] = t
+				case float64:
+					config[k] = strconv.FormatFloat(t, 'f', -1, 64)
+				}
+			}
+		}
+		return d.Set("config", config)
+	}
+
+	block := make(map[string]interface{})
+	for k, s := range fields {
+		raw, ok := j.Config[k]
+		if !ok || raw == nil {
+			continue
+		}
+		switch s.Type {
+		case schema.TypeString:
+			block[k] = fmt.Sprint(raw)
+		case schema.TypeInt:
+			i, err := configInt(raw)
+			if err != nil {
+				return fmt.Errorf("config %s: %s", k, err)
+			}
+			block[k] = i
+		case schema.TypeBool:
+			block[k] = configBool(raw)
+		case schema.TypeMap:
+			if m, ok := raw.(map[string]interface{}); ok {
+				block[k] = m
+			}
+		}
+	}
+	return d.Set(monitoringJobConfigKey(j.Type), []map[string]interface{}{block})
+}
+
+// configInt converts a numeric config value as returned by the API.
+func configInt(v interface{}) (int, error) {
+	switch t := v.(type) {
+	case int:
+		return t, nil
+	case float64:
+		return int(t), nil
+	case string:
+		return strconv.Atoi(t)
+	}
+	return 0, fmt.Errorf("expected a number, got %#v", v)
+}
+
+// configBool converts a boolean config value as returned by the API, which
+// uses both booleans and 0/1 for flags.
+func configBool(v interface{}) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case float64:
+		return t != 0
+	case int:
+		return t != 0
+	case string:
+		return t == "1" || strings.ToLower(t) == "true"
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+package ns1
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+// Maintenance windows are not known to the NS1 API. The provider enforces
+// them whenever the job is applied, by deactivating the job or clearing its
+// notification list while a window is open, and by reporting the job as
+// drifted when a window has opened or closed since.
+
+// monitoringJobMaintenanceSchema returns the maintenance window attributes.
+func monitoringJobMaintenanceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"maintenance_window": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"start": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateRFC3339,
+					},
+					"end": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validateRFC3339,
+					},
+					"pause": {
+						Type:     schema.TypeBool,
+						Optional: true,
+						Default:  false,
+					},
+				},
+			},
+		},
+		"mute_until": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validateRFC3339,
+		},
+		// Computed
+		"in_maintenance": {
+			Type:     schema.TypeBool,
+			Computed: true,
+		},
+	}
+}
+
+// addMonitoringJobMaintenanceSchema adds the maintenance window attributes to
+// the given schema.
+func addMonitoringJobMaintenanceSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for k, v := range monitoringJobMaintenanceSchema() {
+		s[k] = v
+	}
+	return s
+}
+
+// monitoringJobMaintenance returns whether the job is paused, because a
+// maintenance window with pause set is open, and whether its notifications
+// are muted, because any maintenance window is open or mute_until has not
+// passed yet.
+func monitoringJobMaintenance(d *schema.ResourceData, now time.Time) (paused, muted bool, err error) {
+	for _, v := range d.Get("maintenance_window").([]interface{}) {
+		w := v.(map[string]interface{})
+		start, err := time.Parse(time.RFC3339, w["start"].(string))
+		if err != nil {
+			return false, false, err
+		}
+		end, err := time.Parse(time.RFC3339, w["end"].(string))
+		if err != nil {
+			return false, false, err
+		}
+		if !end.After(start) {
+			return false, false, fmt.Errorf("maintenance_window end %s must be after start %s", w["end"], w["start"])
+		}
+		if !now.Before(start) && now.Before(end) {
+			muted = true
+			if w["pause"].(bool) {
+				paused = true
+			}
+		}
+	}
+	if v, ok := d.GetOk("mute_until"); ok {
+		until, err := time.Parse(time.RFC3339, v.(string))
+		if err != nil {
+			return false, false, err
+		}
+		if now.Before(until) {
+			muted = true
+		}
+	}
+	return paused, muted, nil
+}
+
+// resourceDataToMonitoringJobMaintenance deactivates the job or clears its
+// notification list if it is in maintenance.
+func resourceDataToMonitoringJobMaintenance(j *monitor.Job, d *schema.ResourceData) error {
+	paused, muted, err := monitoringJobMaintenance(d, time.Now())
+	if err != nil {
+		return err
+	}
+	if paused {
+		j.Active = false
+	}
+	if muted {
+		j.NotifyListID = ""
+	}
+	return nil
+}
+
+// monitoringJobMaintenanceToResourceData sets active and notify_list. While
+// the job is as expected given its maintenance windows, the configured values
+// are kept. Otherwise values differing from the configured ones are set, so
+// that the job is updated on the next apply.
+func monitoringJobMaintenanceToResourceData(d *schema.ResourceData, j *monitor.Job, now time.Time) error {
+	paused, muted, err := monitoringJobMaintenance(d, now)
+	if err != nil {
+		return err
+	}
+	d.Set("in_maintenance", paused || muted)
+
+	if !paused {
+		d.Set("active", j.Active)
+	} else if j.Active {
+		d.Set("active", !d.Get("active").(bool))
+	}
+
+	notifyList := d.Get("notify_list").(string)
+	if !muted {
+		d.Set("notify_list", j.NotifyListID)
+	} else if j.NotifyListID != "" {
+		if j.NotifyListID == notifyList {
+			d.Set("notify_list", "")
+		} else {
+			d.Set("notify_list", j.NotifyListID)
+		}
+	}
+	return nil
+}
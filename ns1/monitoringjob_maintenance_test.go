@@ -0,0 +1,147 @@
+package ns1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+func TestMonitoringJobMaintenance(t *testing.T) {
+	window := map[string]interface{}{
+		"start": "2018-01-01T10:00:00Z",
+		"end":   "2018-01-01T12:00:00Z",
+	}
+	pausing := map[string]interface{}{
+		"start": "2018-01-01T10:00:00Z",
+		"end":   "2018-01-01T12:00:00Z",
+		"pause": true,
+	}
+	cases := map[string]struct {
+		Config map[string]interface{}
+		Now    string
+		Paused bool
+		Muted  bool
+		Err    bool
+	}{
+		"none": {
+			Config: map[string]interface{}{},
+			Now:    "2018-01-01T11:00:00Z",
+		},
+		"before": {
+			Config: map[string]interface{}{"maintenance_window": []interface{}{window}},
+			Now:    "2018-01-01T09:59:59Z",
+		},
+		"start": {
+			Config: map[string]interface{}{"maintenance_window": []interface{}{window}},
+			Now:    "2018-01-01T10:00:00Z",
+			Muted:  true,
+		},
+		"end": {
+			Config: map[string]interface{}{"maintenance_window": []interface{}{window}},
+			Now:    "2018-01-01T12:00:00Z",
+		},
+		"pause": {
+			Config: map[string]interface{}{"maintenance_window": []interface{}{window, pausing}},
+			Now:    "2018-01-01T11:00:00Z",
+			Paused: true,
+			Muted:  true,
+		},
+		"mute_until": {
+			Config: map[string]interface{}{"mute_until": "2018-01-01T12:00:00Z"},
+			Now:    "2018-01-01T11:00:00Z",
+			Muted:  true,
+		},
+		"mute_until_passed": {
+			Config: map[string]interface{}{"mute_until": "2018-01-01T12:00:00Z"},
+			Now:    "2018-01-01T13:00:00Z",
+		},
+		"empty_window": {
+			Config: map[string]interface{}{"maintenance_window": []interface{}{
+				map[string]interface{}{"start": "2018-01-01T10:00:00Z", "end": "2018-01-01T10:00:00Z"},
+			}},
+			Now: "2018-01-01T11:00:00Z",
+			Err: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, monitoringJobResource().Schema, tc.Config)
+		now, _ := time.Parse(time.RFC3339, tc.Now)
+		paused, muted, err := monitoringJobMaintenance(d, now)
+		if (err != nil) != tc.Err {
+			t.Fatalf("bad: %s, err: %v", tn, err)
+		}
+		if paused != tc.Paused || muted != tc.Muted {
+			t.Fatalf("bad: %s: paused: %t, muted: %t", tn, paused, muted)
+		}
+	}
+}
+
+func TestMonitoringJobMaintenanceToResourceData(t *testing.T) {
+	config := map[string]interface{}{
+		"active":      true,
+		"notify_list": "abc123",
+		"maintenance_window": []interface{}{map[string]interface{}{
+			"start": "2018-01-01T10:00:00Z",
+			"end":   "2018-01-01T12:00:00Z",
+			"pause": true,
+		}},
+	}
+	cases := map[string]struct {
+		Job           *monitor.Job
+		Now           string
+		InMaintenance bool
+		Active        bool
+		NotifyList    string
+	}{
+		"outside_window": {
+			Job:        &monitor.Job{Active: true, NotifyListID: "abc123"},
+			Now:        "2018-01-01T09:00:00Z",
+			Active:     true,
+			NotifyList: "abc123",
+		},
+		"window_closed": {
+			// The job was paused and muted, and is due to be restored.
+			Job:        &monitor.Job{Active: false, NotifyListID: ""},
+			Now:        "2018-01-01T13:00:00Z",
+			Active:     false,
+			NotifyList: "",
+		},
+		"window_opened": {
+			// The job is due to be paused and muted.
+			Job:           &monitor.Job{Active: true, NotifyListID: "abc123"},
+			Now:           "2018-01-01T11:00:00Z",
+			InMaintenance: true,
+			Active:        false,
+			NotifyList:    "",
+		},
+		"in_window": {
+			// The job was paused and muted when the window opened.
+			Job:           &monitor.Job{Active: false, NotifyListID: ""},
+			Now:           "2018-01-01T11:00:00Z",
+			InMaintenance: true,
+			Active:        true,
+			NotifyList:    "abc123",
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, monitoringJobResource().Schema, config)
+		now, _ := time.Parse(time.RFC3339, tc.Now)
+		if err := monitoringJobMaintenanceToResourceData(d, tc.Job, now); err != nil {
+			t.Fatalf("bad: %s, err: %s", tn, err)
+		}
+		if got := d.Get("in_maintenance").(bool); got != tc.InMaintenance {
+			t.Fatalf("bad: %s: in_maintenance: %t", tn, got)
+		}
+		if got := d.Get("active").(bool); got != tc.Active {
+			t.Fatalf("bad: %s: active: %t", tn, got)
+		}
+		if got := d.Get("notify_list").(string); got != tc.NotifyList {
+			t.Fatalf("bad: %s: notify_list: %q", tn, got)
+		}
+	}
+}
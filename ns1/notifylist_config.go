@@ -0,0 +1,184 @@
+package ns1
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+// notifierConfigSchemas holds the typed configuration block for each notifier
+// type, keyed by notifier type. The block attributes map one to one onto the
+// keys of the notifiers' config, see monitor.NewUserNotification and friends.
+// Notifiers of other types are configured with the untyped config map.
+var notifierConfigSchemas = map[string]map[string]*schema.Schema{
+	"user": {
+		"user": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+	"email": {
+		"email": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+	"webhook": {
+		"url": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+	"datafeed": {
+		"sourceid": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+	"pagerduty": {
+		"service_key": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+	},
+	"hipchat": {
+		"token": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"room": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+	"slack": {
+		"url": {
+			Type:      schema.TypeString,
+			Required:  true,
+			Sensitive: true,
+		},
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"channel": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+	},
+}
+
+// notifierConfigKey returns the name of the typed configuration block for
+// the given notifier type.
+func notifierConfigKey(notifierType string) string {
+	return notifierType + "_config"
+}
+
+// notifierConfigKeys returns the names of all typed configuration blocks.
+func notifierConfigKeys() []string {
+	keys := make([]string, 0, len(notifierConfigSchemas))
+	for notifierType := range notifierConfigSchemas {
+		keys = append(keys, notifierConfigKey(notifierType))
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// addNotifierConfigSchema adds a typed configuration block for each notifier
+// type to the given notifier schema.
+func addNotifierConfigSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
+	for notifierType, fields := range notifierConfigSchemas {
+		s[notifierConfigKey(notifierType)] = &schema.Schema{
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: fields,
+			},
+		}
+	}
+	return s
+}
+
+// notifierConfigBlock returns the typed configuration block of a notifier,
+// if set.
+func notifierConfigBlock(ni map[string]interface{}, key string) (map[string]interface{}, bool) {
+	v, ok := ni[key].([]interface{})
+	if !ok || len(v) == 0 || v[0] == nil {
+		return nil, false
+	}
+	return v[0].(map[string]interface{}), true
+}
+
+// notifierToNotification builds a notification from whichever of the typed
+// configuration block or the untyped config map of the notifier is set, and
+// checks that the config of known notifier types has all required keys.
+func notifierToNotification(ni map[string]interface{}) (*monitor.Notification, error) {
+	notifierType := ni["type"].(string)
+	for _, key := range notifierConfigKeys() {
+		if _, ok := notifierConfigBlock(ni, key); ok && key != notifierConfigKey(notifierType) {
+			return nil, fmt.Errorf("%s cannot be used with notifier type %q", key, notifierType)
+		}
+	}
+
+	rawConfig, _ := ni["config"].(map[string]interface{})
+	block, typed := notifierConfigBlock(ni, notifierConfigKey(notifierType))
+	if typed && len(rawConfig) > 0 {
+		return nil, fmt.Errorf("only one of config or %s can be set", notifierConfigKey(notifierType))
+	}
+	if !typed {
+		block = rawConfig
+	}
+
+	config := make(monitor.Config)
+	for k, v := range block {
+		config[k] = v
+	}
+	fields, known := notifierConfigSchemas[notifierType]
+	if !known && len(config) == 0 {
+		return nil, fmt.Errorf("config must be set for notifier type %q", notifierType)
+	}
+	for k, s := range fields {
+		if v, ok := config[k].(string); s.Required && (!ok || v == "") {
+			return nil, fmt.Errorf("%s notifier is missing required config %q", notifierType, k)
+		}
+	}
+	return &monitor.Notification{Type: notifierType, Config: config}, nil
+}
+
+// notificationToNotifier sets the config of a notification on the typed
+// configuration block, unless the notifier in the state uses the untyped
+// config map or the notifier type is not known.
+func notificationToNotifier(n *monitor.Notification, prior map[string]interface{}) map[string]interface{} {
+	ni := map[string]interface{}{
+		"type": n.Type,
+	}
+	config := make(map[string]interface{})
+	for k, v := range n.Config {
+		if v != nil {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+
+	fields, typed := notifierConfigSchemas[n.Type]
+	if rawConfig, ok := prior["config"].(map[string]interface{}); ok && len(rawConfig) > 0 {
+		typed = false
+	}
+	if !typed {
+		ni["config"] = config
+		return ni
+	}
+	block := make(map[string]interface{})
+	for k := range fields {
+		if v, ok := config[k]; ok {
+			block[k] = v
+		}
+	}
+	ni[notifierConfigKey(n.Type)] = []map[string]interface{}{block}
+	return ni
+}
@@ -1,184 +1,405 @@
 package ns1
 
 import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
 	"github.com/hashicorp/terraform/helper/schema"
-	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 )
 
+// permissionFamilies holds the boolean permissions of each family of
+// permissions, keyed by family. Families and permissions are named as in the
+// API, see permissionsMap.
+var permissionFamilies = map[string][]string{
+	"dns": {
+		"view_zones",
+		"manage_zones",
+		"zones_allow_by_default",
+	},
+	"data": {
+		"push_to_datafeeds",
+		"manage_datasources",
+		"manage_datafeeds",
+	},
+	"account": {
+		"manage_users",
+		"manage_payment_methods",
+		"manage_plan",
+		"manage_teams",
+		"manage_apikeys",
+		"manage_account_settings",
+		"view_activity_log",
+		"view_invoices",
+		"manage_ip_whitelist",
+	},
+	"monitoring": {
+		"manage_lists",
+		"manage_jobs",
+		"view_jobs",
+	},
+	"security": {
+		"manage_global_2fa",
+		"manage_active_directory",
+	},
+	"dhcp": {
+		"manage_dhcp",
+		"view_dhcp",
+	},
+	"ipam": {
+		"manage_ipam",
+		"view_ipam",
+	},
+}
+
 func addPermsSchema(s map[string]*schema.Schema) map[string]*schema.Schema {
-	s["dns_view_zones"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
+	s["role"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ValidateFunc: roleStringEnum.ValidateFunc,
 	}
-	s["dns_manage_zones"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
+	s["permissions"] = permissionsSchema(false)
+	return s
+}
+
+// permissionsSchema returns the schema of a permissions block, with a block
+// for each family of permissions. If computed is set all attributes are
+// computed, as for the effective_permissions of users and API keys.
+//
+// The boolean permissions of a configurable block are strings, since
+// helper/schema reads unset booleans as false, and an explicit false has to
+// override a permission granted by the role while an unset permission leaves
+// it to the role.
+func permissionsSchema(computed bool) *schema.Schema {
+	families := make(map[string]*schema.Schema)
+	for family, perms := range permissionFamilies {
+		fields := make(map[string]*schema.Schema)
+		for _, p := range perms {
+			if computed {
+				fields[p] = &schema.Schema{
+					Type:     schema.TypeBool,
+					Computed: true,
+				}
+				continue
+			}
+			fields[p] = &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePermissionValue,
+				StateFunc:    normalizePermissionValue,
+			}
+		}
+		if family == "dns" {
+			for _, k := range []string{"zones_allow", "zones_deny"} {
+				fields[k] = &schema.Schema{
+					Type:     schema.TypeList,
+					Optional: !computed,
+					Computed: computed,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				}
+			}
+			for _, k := range []string{"records_allow", "records_deny"} {
+				fields[k] = permissionsRecordsSchema(computed)
+			}
+		}
+		families[family] = optionalBlockSchema(fields, computed)
 	}
-	s["dns_zones_allow_by_default"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
+	return optionalBlockSchema(families, computed)
+}
+
+func validatePermissionValue(v interface{}, k string) (ws []string, es []error) {
+	if _, err := strconv.ParseBool(v.(string)); err != nil {
+		es = append(es, fmt.Errorf("%q must be true or false, got: %q", k, v.(string)))
 	}
-	s["dns_zones_deny"] = &schema.Schema{
+	return
+}
+
+// normalizePermissionValue stores booleans, which are read from the
+// configuration as "1" and "0", as "true" and "false".
+func normalizePermissionValue(v interface{}) string {
+	b, err := strconv.ParseBool(v.(string))
+	if err != nil {
+		return v.(string)
+	}
+	return strconv.FormatBool(b)
+}
+
+// permissionsRecordsSchema returns the schema of the records DNS permissions
+// are scoped to.
+func permissionsRecordsSchema(computed bool) *schema.Schema {
+	return &schema.Schema{
 		Type:     schema.TypeList,
-		Optional: true,
-		Elem:     &schema.Schema{Type: schema.TypeString},
+		Optional: !computed,
+		Computed: computed,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"domain": {
+					Type:     schema.TypeString,
+					Required: !computed,
+					Computed: computed,
+				},
+				"subdomains": {
+					Type:     schema.TypeBool,
+					Optional: !computed,
+					Computed: computed,
+				},
+				"zone": {
+					Type:     schema.TypeString,
+					Required: !computed,
+					Computed: computed,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Required: !computed,
+					Computed: computed,
+				},
+			},
+		},
 	}
-	s["dns_zones_allow"] = &schema.Schema{
+}
+
+// optionalBlockSchema returns the schema of a block holding fields, that is
+// optional unless computed is set.
+func optionalBlockSchema(fields map[string]*schema.Schema, computed bool) *schema.Schema {
+	s := &schema.Schema{
 		Type:     schema.TypeList,
-		Optional: true,
-		Elem:     &schema.Schema{Type: schema.TypeString},
-	}
-	s["data_push_to_datafeeds"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
+		Optional: !computed,
+		Computed: computed,
+		Elem: &schema.Resource{
+			Schema: fields,
+		},
 	}
-	s["data_manage_datasources"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["data_manage_datafeeds"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_users"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_payment_methods"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_plan"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_teams"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_apikeys"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_manage_account_settings"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_view_activity_log"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["account_view_invoices"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["monitoring_manage_lists"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["monitoring_manage_jobs"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
-	}
-	s["monitoring_view_jobs"] = &schema.Schema{
-		Type:     schema.TypeBool,
-		Optional: true,
+	if !computed {
+		s.MaxItems = 1
 	}
 	return s
 }
 
-func permissionsToResourceData(d *schema.ResourceData, permissions account.PermissionsMap) {
-	d.Set("dns_view_zones", permissions.DNS.ViewZones)
-	d.Set("dns_manage_zones", permissions.DNS.ManageZones)
-	d.Set("dns_zones_allow_by_default", permissions.DNS.ZonesAllowByDefault)
-	d.Set("dns_zones_deny", permissions.DNS.ZonesDeny)
-	d.Set("dns_zones_allow", permissions.DNS.ZonesAllow)
-	d.Set("data_push_to_datafeeds", permissions.Data.PushToDatafeeds)
-	d.Set("data_manage_datasources", permissions.Data.ManageDatasources)
-	d.Set("data_manage_datafeeds", permissions.Data.ManageDatafeeds)
-	d.Set("account_manage_users", permissions.Account.ManageUsers)
-	d.Set("account_manage_payment_methods", permissions.Account.ManagePaymentMethods)
-	d.Set("account_manage_plan", permissions.Account.ManagePlan)
-	d.Set("account_manage_teams", permissions.Account.ManageTeams)
-	d.Set("account_manage_apikeys", permissions.Account.ManageApikeys)
-	d.Set("account_manage_account_settings", permissions.Account.ManageAccountSettings)
-	d.Set("account_view_activity_log", permissions.Account.ViewActivityLog)
-	d.Set("account_view_invoices", permissions.Account.ViewInvoices)
-	d.Set("monitoring_manage_lists", permissions.Monitoring.ManageLists)
-	d.Set("monitoring_manage_jobs", permissions.Monitoring.ManageJobs)
-	d.Set("monitoring_view_jobs", permissions.Monitoring.ViewJobs)
-}
-
-func resourceDataToPermissions(d *schema.ResourceData) account.PermissionsMap {
-	var p account.PermissionsMap
-	if v, ok := d.GetOk("dns_view_zones"); ok {
-		p.DNS.ViewZones = v.(bool)
-	}
-	if v, ok := d.GetOk("dns_manage_zones"); ok {
-		p.DNS.ManageZones = v.(bool)
-	}
-	if v, ok := d.GetOk("dns_zones_allow_by_default"); ok {
-		p.DNS.ZonesAllowByDefault = v.(bool)
-	}
-	if v, ok := d.GetOk("dns_zones_deny"); ok {
-		denyRaw := v.([]interface{})
-		p.DNS.ZonesDeny = make([]string, len(denyRaw))
-		for i, deny := range denyRaw {
-			p.DNS.ZonesDeny[i] = deny.(string)
+// permissionsToFields returns the fields of each family of the permissions,
+// keyed by family, with all lists set.
+func permissionsToFields(p permissionsMap) map[string]map[string]interface{} {
+	b, _ := json.Marshal(p)
+	var m map[string]map[string]interface{}
+	json.Unmarshal(b, &m)
+	for _, fields := range m {
+		for k, v := range fields {
+			if v == nil {
+				fields[k] = []interface{}{}
+			}
 		}
-	} else {
-		p.DNS.ZonesDeny = make([]string, 0)
 	}
-	if v, ok := d.GetOk("dns_zones_allow"); ok {
-		allowRaw := v.([]interface{})
-		p.DNS.ZonesAllow = make([]string, len(allowRaw))
-		for i, allow := range allowRaw {
-			p.DNS.ZonesAllow[i] = allow.(string)
+	return m
+}
+
+// fieldsToPermissions is the inverse of permissionsToFields.
+func fieldsToPermissions(m map[string]map[string]interface{}) permissionsMap {
+	var p permissionsMap
+	b, _ := json.Marshal(m)
+	json.Unmarshal(b, &p)
+	return p
+}
+
+// permissionsToList returns the permissions as the value of a permissions
+// block.
+func permissionsToList(p permissionsMap) []interface{} {
+	families := make(map[string]interface{})
+	for family, fields := range permissionsToFields(p) {
+		families[family] = []interface{}{fields}
+	}
+	return []interface{}{families}
+}
+
+// resourceDataToFields returns the fields of each family set in the
+// permissions block, with the boolean permissions parsed and those that are
+// not set left out.
+func resourceDataToFields(d *schema.ResourceData) map[string]map[string]interface{} {
+	m := make(map[string]map[string]interface{})
+	l := d.Get("permissions").([]interface{})
+	if len(l) == 0 || l[0] == nil {
+		return m
+	}
+	for family, v := range l[0].(map[string]interface{}) {
+		block := v.([]interface{})
+		if len(block) == 0 || block[0] == nil {
+			continue
 		}
-	} else {
-		p.DNS.ZonesAllow = make([]string, 0)
-	}
-	if v, ok := d.GetOk("data_push_to_datafeeds"); ok {
-		p.Data.PushToDatafeeds = v.(bool)
-	}
-	if v, ok := d.GetOk("data_manage_datasources"); ok {
-		p.Data.ManageDatasources = v.(bool)
-	}
-	if v, ok := d.GetOk("data_manage_datafeeds"); ok {
-		p.Data.ManageDatafeeds = v.(bool)
-	}
-	if v, ok := d.GetOk("account_manage_users"); ok {
-		p.Account.ManageUsers = v.(bool)
-	}
-	if v, ok := d.GetOk("account_manage_payment_methods"); ok {
-		p.Account.ManagePaymentMethods = v.(bool)
+		fields := make(map[string]interface{})
+		for k, v := range block[0].(map[string]interface{}) {
+			if s, ok := v.(string); ok {
+				if s == "" {
+					continue
+				}
+				v, _ = strconv.ParseBool(s)
+			}
+			fields[k] = v
+		}
+		m[family] = fields
 	}
-	if v, ok := d.GetOk("account_manage_plan"); ok {
-		p.Account.ManagePlan = v.(bool)
+	return m
+}
+
+// permissionGranted returns whether the value of a permission grants
+// anything, that is whether it is true or a non-empty list.
+func permissionGranted(v interface{}) bool {
+	switch t := v.(type) {
+	case bool:
+		return t
+	case []interface{}:
+		return len(t) > 0
+	}
+	return false
+}
+
+// permissionsToResourceData sets the permissions as returned by the API.
+// Permissions granted by inherited, the permissions of the teams of a user
+// or API key, are left as they are in the state, since the API returns
+// effective permissions and those would otherwise show up as changes to the
+// explicitly set permissions. Boolean permissions that are not set and match
+// the role are left unset. Families of permissions that are not in the state
+// and set nothing are left out.
+func permissionsToResourceData(d *schema.ResourceData, permissions permissionsMap, inherited permissionsMap) error {
+	var role permissionsMap
+	if v, ok := d.GetOk("role"); ok {
+		role = permissionRoles[v.(string)]
+	}
+	teams := permissionsToFields(inherited)
+	roles := permissionsToFields(role)
+	current := resourceDataToFields(d)
+
+	families := make(map[string]interface{})
+	for family, fields := range permissionsToFields(permissions) {
+		cur, inState := current[family]
+		set := false
+		for k, v := range fields {
+			c, isSet := cur[k]
+			switch t := v.(type) {
+			case bool:
+				switch {
+				case permissionGranted(teams[family][k]):
+					v = ""
+					if isSet {
+						v = strconv.FormatBool(c.(bool))
+					}
+				case !isSet && t == permissionGranted(roles[family][k]):
+					v = ""
+				default:
+					v = strconv.FormatBool(t)
+				}
+			case []interface{}:
+				if permissionGranted(teams[family][k]) || permissionGranted(roles[family][k]) {
+					v = []interface{}{}
+					if isSet {
+						v = c
+					}
+				}
+			}
+			fields[k] = v
+			set = set || permissionSet(v)
+		}
+		if inState || set {
+			families[family] = []interface{}{fields}
+		}
 	}
-	if v, ok := d.GetOk("account_manage_teams"); ok {
-		p.Account.ManageTeams = v.(bool)
+
+	if len(families) == 0 && len(d.Get("permissions").([]interface{})) == 0 {
+		return d.Set("permissions", []interface{}{})
 	}
-	if v, ok := d.GetOk("account_manage_apikeys"); ok {
-		p.Account.ManageApikeys = v.(bool)
+	return d.Set("permissions", []interface{}{families})
+}
+
+// permissionSet returns whether the value of a permission in the
+// permissions block is set, that is whether it is a boolean permission that
+// is not empty or a non-empty list.
+func permissionSet(v interface{}) bool {
+	switch t := v.(type) {
+	case string:
+		return t != ""
+	case []interface{}:
+		return len(t) > 0
+	}
+	return false
+}
+
+// effectivePermissionsSchema returns the schema of the computed
+// effective_permissions of users and API keys.
+func effectivePermissionsSchema() *schema.Schema {
+	return permissionsSchema(true)
+}
+
+// effectivePermissionsToResourceData sets the effective permissions of a
+// user or API key, as returned by the API.
+func effectivePermissionsToResourceData(d *schema.ResourceData, permissions permissionsMap) error {
+	return d.Set("effective_permissions", permissionsToList(permissions))
+}
+
+// teamsPermissions returns the permissions granted by any of the given
+// teams.
+func teamsPermissions(client *ns1.Client, teamIDs []string) (permissionsMap, error) {
+	var inherited permissionsMap
+	for _, id := range teamIDs {
+		t, _, err := newAccountService(client).GetTeam(id)
+		if err != nil {
+			return inherited, err
+		}
+		inherited = mergePermissions(inherited, t.Permissions)
 	}
-	if v, ok := d.GetOk("account_manage_account_settings"); ok {
-		p.Account.ManageAccountSettings = v.(bool)
+	return inherited, nil
+}
+
+// mergePermissions returns the permissions granted by either of a or b.
+func mergePermissions(a, b permissionsMap) permissionsMap {
+	fa := permissionsToFields(a)
+	for family, fields := range permissionsToFields(b) {
+		for k, v := range fields {
+			switch t := v.(type) {
+			case bool:
+				fa[family][k] = fa[family][k].(bool) || t
+			case []interface{}:
+				fa[family][k] = append(fa[family][k].([]interface{}), t...)
+			}
+		}
 	}
-	if v, ok := d.GetOk("account_view_activity_log"); ok {
-		p.Account.ViewActivityLog = v.(bool)
+	return fieldsToPermissions(fa)
+}
+
+// overlayPermissions returns the permissions of p, with the boolean
+// permissions set in fields replacing those of p, and the lists in fields
+// added to those of p.
+func overlayPermissions(p permissionsMap, fields map[string]map[string]interface{}) permissionsMap {
+	m := permissionsToFields(p)
+	for family, fs := range fields {
+		for k, v := range fs {
+			if l, ok := v.([]interface{}); ok {
+				m[family][k] = append(m[family][k].([]interface{}), l...)
+			} else {
+				m[family][k] = v
+			}
+		}
 	}
-	if v, ok := d.GetOk("account_view_invoices"); ok {
-		p.Account.ViewInvoices = v.(bool)
+	return fieldsToPermissions(m)
+}
+
+// resourceDataToPermissions returns the permissions of the role, if any,
+// overridden by the permissions set explicitly.
+func resourceDataToPermissions(d *schema.ResourceData) permissionsMap {
+	var role permissionsMap
+	if v, ok := d.GetOk("role"); ok {
+		role = permissionRoles[v.(string)]
+	}
+	p := overlayPermissions(role, resourceDataToFields(d))
+	if p.DNS.ZonesAllow == nil {
+		p.DNS.ZonesAllow = make([]string, 0)
 	}
-	if v, ok := d.GetOk("monitoring_manage_lists"); ok {
-		p.Monitoring.ManageLists = v.(bool)
+	if p.DNS.ZonesDeny == nil {
+		p.DNS.ZonesDeny = make([]string, 0)
 	}
-	if v, ok := d.GetOk("monitoring_manage_jobs"); ok {
-		p.Monitoring.ManageJobs = v.(bool)
+	if p.DNS.RecordsAllow == nil {
+		p.DNS.RecordsAllow = make([]permissionsRecord, 0)
 	}
-	if v, ok := d.GetOk("monitoring_view_jobs"); ok {
-		p.Monitoring.ViewJobs = v.(bool)
+	if p.DNS.RecordsDeny == nil {
+		p.DNS.RecordsDeny = make([]permissionsRecord, 0)
 	}
 	return p
 }
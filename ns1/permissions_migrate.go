@@ -0,0 +1,131 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// permissionsV0 holds the flat permission attributes of version 0 of the
+// schemas of users, teams and API keys. Each is named after its family and
+// permission in the permissions block, joined by an underscore.
+var permissionsV0 = []string{
+	"dns_view_zones",
+	"dns_manage_zones",
+	"dns_zones_allow_by_default",
+	"dns_zones_deny",
+	"dns_zones_allow",
+	"data_push_to_datafeeds",
+	"data_manage_datasources",
+	"data_manage_datafeeds",
+	"account_manage_users",
+	"account_manage_payment_methods",
+	"account_manage_plan",
+	"account_manage_teams",
+	"account_manage_apikeys",
+	"account_manage_account_settings",
+	"account_view_activity_log",
+	"account_view_invoices",
+	"monitoring_manage_lists",
+	"monitoring_manage_jobs",
+	"monitoring_view_jobs",
+}
+
+// migratePermissionsState migrates the state of users, teams and API keys.
+func migratePermissionsState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found NS1 permissions State v0; migrating to v1")
+		var err error
+		if is, err = migratePermissionsStateV0toV1(is); err != nil {
+			return is, err
+		}
+		fallthrough
+	case 1:
+		log.Println("[INFO] Found NS1 permissions State v1; migrating to v2")
+		return migratePermissionsStateV1toV2(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migratePermissionsStateV0toV1 moves the flat permission attributes into
+// the permissions block. Permissions that grant nothing are dropped, as are
+// the effective permissions, which are set again on refresh.
+func migratePermissionsStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] Attributes before migration: %#v", redactAttributes(is.Attributes, permissionsResourceSchemas()...))
+
+	families := make(map[string]bool)
+	for _, flat := range permissionsV0 {
+		parts := strings.SplitN(flat, "_", 2)
+		family, perm := parts[0], parts[1]
+		prefix := fmt.Sprintf("permissions.0.%s.0.%s", family, perm)
+
+		if v, ok := is.Attributes[flat]; ok {
+			if v == "true" {
+				is.Attributes[prefix] = v
+				families[family] = true
+			}
+			delete(is.Attributes, flat)
+		}
+
+		if n, ok := is.Attributes[flat+".#"]; ok {
+			if n != "0" {
+				for k, v := range is.Attributes {
+					if strings.HasPrefix(k, flat+".") {
+						is.Attributes[prefix+strings.TrimPrefix(k, flat)] = v
+					}
+				}
+				families[family] = true
+			}
+			for k := range is.Attributes {
+				if strings.HasPrefix(k, flat+".") {
+					delete(is.Attributes, k)
+				}
+			}
+		}
+	}
+
+	for family := range families {
+		is.Attributes[fmt.Sprintf("permissions.0.%s.#", family)] = "1"
+	}
+	if len(families) > 0 {
+		is.Attributes["permissions.#"] = "1"
+	}
+
+	for k := range is.Attributes {
+		if strings.HasPrefix(k, "effective_permissions.") {
+			delete(is.Attributes, k)
+		}
+	}
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", redactAttributes(is.Attributes, permissionsResourceSchemas()...))
+	return is, nil
+}
+
+// migratePermissionsStateV1toV2 unsets the boolean permissions that are
+// false. Until v2 these could not be told apart from unset permissions, and
+// an explicit false now overrides the role.
+func migratePermissionsStateV1toV2(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() || is.Attributes == nil {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	for family, perms := range permissionFamilies {
+		for _, perm := range perms {
+			k := fmt.Sprintf("permissions.0.%s.0.%s", family, perm)
+			if is.Attributes[k] == "false" {
+				delete(is.Attributes, k)
+			}
+		}
+	}
+	return is, nil
+}
@@ -0,0 +1,87 @@
+package ns1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestPermissionsMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		StateVersion int
+		Attributes   map[string]string
+		Expected     map[string]string
+	}{
+		"v0_1_flat": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":                       "example",
+				"dns_view_zones":             "true",
+				"dns_manage_zones":           "false",
+				"dns_zones_allow_by_default": "true",
+				"dns_zones_allow.#":          "1",
+				"dns_zones_allow.0":          "mytest.zone",
+				"dns_zones_deny.#":           "0",
+				"data_manage_datasources":    "false",
+				"monitoring_view_jobs":       "true",
+			},
+			Expected: map[string]string{
+				"name":                           "example",
+				"permissions.#":                  "1",
+				"permissions.0.dns.#":            "1",
+				"permissions.0.dns.0.view_zones": "true",
+				"permissions.0.dns.0.zones_allow_by_default": "true",
+				"permissions.0.dns.0.zones_allow.#":          "1",
+				"permissions.0.dns.0.zones_allow.0":          "mytest.zone",
+				"permissions.0.monitoring.#":                 "1",
+				"permissions.0.monitoring.0.view_jobs":       "true",
+			},
+		},
+		"v1_2_false": {
+			StateVersion: 1,
+			Attributes: map[string]string{
+				"name":                                 "example",
+				"role":                                 "full_admin",
+				"permissions.#":                        "1",
+				"permissions.0.account.#":              "1",
+				"permissions.0.account.0.manage_users": "false",
+				"permissions.0.account.0.manage_plan":  "true",
+			},
+			Expected: map[string]string{
+				"name":                                "example",
+				"role":                                "full_admin",
+				"permissions.#":                       "1",
+				"permissions.0.account.#":             "1",
+				"permissions.0.account.0.manage_plan": "true",
+			},
+		},
+		"v0_1_effective": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":                                   "example",
+				"account_view_invoices":                  "false",
+				"effective_permissions.#":                "1",
+				"effective_permissions.0.dns_view_zones": "true",
+			},
+			Expected: map[string]string{
+				"name": "example",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         "i-abc123",
+			Attributes: tc.Attributes,
+		}
+		is, err := migratePermissionsState(tc.StateVersion, is, nil)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+
+		if !reflect.DeepEqual(is.Attributes, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, is.Attributes)
+		}
+	}
+}
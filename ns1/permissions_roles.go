@@ -0,0 +1,109 @@
+package ns1
+
+import (
+	"sort"
+
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+// permissionRoles holds the built-in permission presets that can be set with
+// the role argument of users, teams and API keys, keyed by role name.
+var permissionRoles = map[string]permissionsMap{
+	"read_only": {
+		DNS: permissionsDNS{
+			PermissionsDNS: account.PermissionsDNS{
+				ViewZones: true,
+			},
+		},
+		Account: permissionsAccount{
+			PermissionsAccount: account.PermissionsAccount{
+				ViewActivityLog: true,
+				ViewInvoices:    true,
+			},
+		},
+		Monitoring: account.PermissionsMonitoring{
+			ViewJobs: true,
+		},
+		DHCP: permissionsDHCP{
+			ViewDHCP: true,
+		},
+		IPAM: permissionsIPAM{
+			ViewIPAM: true,
+		},
+	},
+	"dns_admin": {
+		DNS: permissionsDNS{
+			PermissionsDNS: account.PermissionsDNS{
+				ViewZones:   true,
+				ManageZones: true,
+			},
+		},
+		Data: account.PermissionsData{
+			PushToDatafeeds:   true,
+			ManageDatasources: true,
+			ManageDatafeeds:   true,
+		},
+	},
+	"monitoring_admin": {
+		Monitoring: account.PermissionsMonitoring{
+			ManageLists: true,
+			ManageJobs:  true,
+			ViewJobs:    true,
+		},
+	},
+	"full_admin": {
+		DNS: permissionsDNS{
+			PermissionsDNS: account.PermissionsDNS{
+				ViewZones:   true,
+				ManageZones: true,
+			},
+		},
+		Data: account.PermissionsData{
+			PushToDatafeeds:   true,
+			ManageDatasources: true,
+			ManageDatafeeds:   true,
+		},
+		Account: permissionsAccount{
+			PermissionsAccount: account.PermissionsAccount{
+				ManageUsers:           true,
+				ManagePaymentMethods:  true,
+				ManagePlan:            true,
+				ManageTeams:           true,
+				ManageApikeys:         true,
+				ManageAccountSettings: true,
+				ViewActivityLog:       true,
+				ViewInvoices:          true,
+			},
+			ManageIPWhitelist: true,
+		},
+		Monitoring: account.PermissionsMonitoring{
+			ManageLists: true,
+			ManageJobs:  true,
+			ViewJobs:    true,
+		},
+		Security: permissionsSecurity{
+			ManageGlobal2FA:       true,
+			ManageActiveDirectory: true,
+		},
+		DHCP: permissionsDHCP{
+			ManageDHCP: true,
+			ViewDHCP:   true,
+		},
+		IPAM: permissionsIPAM{
+			ManageIPAM: true,
+			ViewIPAM:   true,
+		},
+	},
+}
+
+var roleStringEnum *StringEnum = NewStringEnum(roleNames())
+
+// roleNames returns the sorted names of the built-in permission presets.
+func roleNames() []string {
+	names := make([]string, 0, len(permissionRoles))
+	for name := range permissionRoles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
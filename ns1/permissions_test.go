@@ -0,0 +1,165 @@
+package ns1
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// testPermissionsConfig returns the configuration of a team with the role
+// and the given account permissions.
+func testPermissionsConfig(role string, account map[string]interface{}) map[string]interface{} {
+	c := map[string]interface{}{"name": "team"}
+	if role != "" {
+		c["role"] = role
+	}
+	if account != nil {
+		c["permissions"] = []interface{}{map[string]interface{}{
+			"account": []interface{}{account},
+		}}
+	}
+	return c
+}
+
+func TestResourceDataToPermissions(t *testing.T) {
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		ManageUsers bool
+		ManageTeams bool
+	}{
+		"role": {
+			Config:      testPermissionsConfig("full_admin", nil),
+			ManageUsers: true,
+			ManageTeams: true,
+		},
+		"explicit_false": {
+			Config:      testPermissionsConfig("full_admin", map[string]interface{}{"manage_users": "false"}),
+			ManageUsers: false,
+			ManageTeams: true,
+		},
+		"explicit_true": {
+			Config:      testPermissionsConfig("read_only", map[string]interface{}{"manage_users": "true"}),
+			ManageUsers: true,
+			ManageTeams: false,
+		},
+		"unset": {
+			Config:      testPermissionsConfig("full_admin", map[string]interface{}{"manage_users": ""}),
+			ManageUsers: true,
+			ManageTeams: true,
+		},
+		"no_role": {
+			Config:      testPermissionsConfig("", map[string]interface{}{"manage_users": "true"}),
+			ManageUsers: true,
+			ManageTeams: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, teamResource().Schema, tc.Config)
+		p := resourceDataToPermissions(d)
+		if p.Account.ManageUsers != tc.ManageUsers || p.Account.ManageTeams != tc.ManageTeams {
+			t.Fatalf("bad: %s: manage_users: %t, manage_teams: %t", tn, p.Account.ManageUsers, p.Account.ManageTeams)
+		}
+	}
+}
+
+func TestOverlayPermissions(t *testing.T) {
+	var p permissionsMap
+	p.DNS.ViewZones = true
+	p.DNS.ZonesAllow = []string{"example.com"}
+	p.DNS.RecordsAllow = []permissionsRecord{{Domain: "www.example.com", Zone: "example.com", RecordType: "A"}}
+	fields := map[string]map[string]interface{}{
+		"dns": {
+			"view_zones":  false,
+			"zones_allow": []interface{}{"example.net"},
+			"records_allow": []interface{}{map[string]interface{}{
+				"domain":     "www.example.net",
+				"subdomains": true,
+				"zone":       "example.net",
+				"type":       "A",
+			}},
+		},
+	}
+
+	overlaid := overlayPermissions(p, fields)
+	if overlaid.DNS.ViewZones {
+		t.Fatal("bad: view_zones was not revoked")
+	}
+	if expected := []string{"example.com", "example.net"}; !reflect.DeepEqual(overlaid.DNS.ZonesAllow, expected) {
+		t.Fatalf("bad: zones_allow\n\n expected: %#v\n got: %#v", expected, overlaid.DNS.ZonesAllow)
+	}
+	expected := []permissionsRecord{
+		{Domain: "www.example.com", Zone: "example.com", RecordType: "A"},
+		{Domain: "www.example.net", Subdomains: true, Zone: "example.net", RecordType: "A"},
+	}
+	if !reflect.DeepEqual(overlaid.DNS.RecordsAllow, expected) {
+		t.Fatalf("bad: records_allow\n\n expected: %#v\n got: %#v", expected, overlaid.DNS.RecordsAllow)
+	}
+	if !p.DNS.ViewZones || len(p.DNS.ZonesAllow) != 1 {
+		t.Fatalf("bad: permissions were modified: %#v", p.DNS)
+	}
+}
+
+func TestPermissionsToResourceData(t *testing.T) {
+	var inherited permissionsMap
+	inherited.Account.ManageTeams = true
+	cases := map[string]struct {
+		Config      map[string]interface{}
+		Inherited   permissionsMap
+		Permissions int
+		ManageUsers string
+		ManageTeams string
+		ManageZones string
+	}{
+		"role": {
+			// Values matching the role are left empty, and families
+			// setting nothing are left out.
+			Config:      testPermissionsConfig("full_admin", nil),
+			Permissions: 0,
+		},
+		"explicit_false": {
+			Config:      testPermissionsConfig("full_admin", map[string]interface{}{"manage_users": "false"}),
+			Permissions: 1,
+			ManageUsers: "false",
+		},
+		"explicit_true": {
+			// Without a role, unset permissions match the role as long as
+			// they are not granted.
+			Config:      testPermissionsConfig("", map[string]interface{}{"manage_users": "true"}),
+			Permissions: 1,
+			ManageUsers: "true",
+		},
+		"inherited": {
+			// Permissions granted by teams are left as they are in the
+			// state.
+			Config:      testPermissionsConfig("", map[string]interface{}{"manage_users": "true"}),
+			Inherited:   inherited,
+			Permissions: 1,
+			ManageUsers: "true",
+			ManageTeams: "",
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataRaw(t, teamResource().Schema, tc.Config)
+		// The API returns the permissions as sent, along with those granted
+		// by the teams.
+		permissions := mergePermissions(resourceDataToPermissions(d), tc.Inherited)
+		if err := permissionsToResourceData(d, permissions, tc.Inherited); err != nil {
+			t.Fatalf("bad: %s, err: %s", tn, err)
+		}
+		if got := len(d.Get("permissions").([]interface{})); got != tc.Permissions {
+			t.Fatalf("bad: %s: permissions\n\n expected: %d blocks\n got: %d", tn, tc.Permissions, got)
+		}
+		for k, expected := range map[string]string{
+			"permissions.0.account.0.manage_users": tc.ManageUsers,
+			"permissions.0.account.0.manage_teams": tc.ManageTeams,
+			"permissions.0.dns.0.manage_zones":     tc.ManageZones,
+		} {
+			if got := d.Get(k).(string); got != expected {
+				t.Fatalf("bad: %s: %s\n\n expected: %#v\n got: %#v", tn, k, expected, got)
+			}
+		}
+	}
+}
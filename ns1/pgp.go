@@ -0,0 +1,101 @@
+package ns1
+
+import (
+	"bytes"
+	// openpgp.Encrypt requires a hash preferred by the key to be available.
+	_ "crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/packet"
+)
+
+// keybaseLookupURL is the keybase API endpoint public keys are looked up at.
+const keybaseLookupURL = "https://keybase.io/_/api/1.0/user/lookup.json"
+
+// retrievePGPKey returns the public key given as either a base64 encoded
+// public key or "keybase:<username>".
+func retrievePGPKey(s string) (*openpgp.Entity, error) {
+	if strings.HasPrefix(s, "keybase:") {
+		return fetchKeybasePGPKey(strings.TrimPrefix(s, "keybase:"))
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("pgp_key must be a base64 encoded public key or keybase:<username>: %s", err)
+	}
+	entity, err := openpgp.ReadEntity(packet.NewReader(bytes.NewReader(b)))
+	if err != nil {
+		return nil, fmt.Errorf("pgp_key is not a valid public key: %s", err)
+	}
+	return entity, nil
+}
+
+// fetchKeybasePGPKey returns the primary public key of the keybase user.
+func fetchKeybasePGPKey(username string) (*openpgp.Entity, error) {
+	q := url.Values{}
+	q.Set("usernames", username)
+	q.Set("fields", "public_keys")
+	resp, err := http.Get(keybaseLookupURL + "?" + q.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("looking up keybase user %s: %s", username, err)
+	}
+	defer resp.Body.Close()
+
+	var lookup struct {
+		Status struct {
+			Code int    `json:"code"`
+			Name string `json:"name"`
+		} `json:"status"`
+		Them []struct {
+			PublicKeys struct {
+				Primary struct {
+					Bundle string `json:"bundle"`
+				} `json:"primary"`
+			} `json:"public_keys"`
+		} `json:"them"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&lookup); err != nil {
+		return nil, fmt.Errorf("looking up keybase user %s: %s", username, err)
+	}
+	if lookup.Status.Code != 0 {
+		return nil, fmt.Errorf("looking up keybase user %s: %s", username, lookup.Status.Name)
+	}
+	if len(lookup.Them) != 1 || lookup.Them[0].PublicKeys.Primary.Bundle == "" {
+		return nil, fmt.Errorf("keybase user %s has no public key", username)
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(lookup.Them[0].PublicKeys.Primary.Bundle))
+	if err != nil {
+		return nil, fmt.Errorf("keybase user %s: %s", username, err)
+	}
+	return entities[0], nil
+}
+
+// pgpKeyFingerprint returns the hex encoded fingerprint of the public key.
+func pgpKeyFingerprint(entity *openpgp.Entity) string {
+	return hex.EncodeToString(entity.PrimaryKey.Fingerprint[:])
+}
+
+// encryptValue encrypts the value with the public key, and returns it base64
+// encoded.
+func encryptValue(entity *openpgp.Entity, value string) (string, error) {
+	var buf bytes.Buffer
+	w, err := openpgp.Encrypt(&buf, []*openpgp.Entity{entity}, nil, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write([]byte(value)); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
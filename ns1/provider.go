@@ -16,6 +16,7 @@ func Provider() terraform.ResourceProvider {
 			"apikey": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				DefaultFunc: schema.EnvDefaultFunc("NS1_APIKEY", nil),
 				Description: descriptions["api_key"],
 			},
@@ -33,17 +34,29 @@ func Provider() terraform.ResourceProvider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"ns1_zone":          zoneResource(),
-			"ns1_record":        recordResource(),
-			"ns1_answer":        answerResource(),
-			"ns1_region":        regionResource(),
-			"ns1_datasource":    dataSourceResource(),
-			"ns1_datafeed":      dataFeedResource(),
-			"ns1_monitoringjob": monitoringJobResource(),
-			"ns1_notifylist":    notifyListResource(),
-			"ns1_user":          userResource(),
-			"ns1_apikey":        apikeyResource(),
-			"ns1_team":          teamResource(),
+			"ns1_zone":            zoneResource(),
+			"ns1_zone_records":    zoneRecordsResource(),
+			"ns1_record":          recordResource(),
+			"ns1_answer":          answerResource(),
+			"ns1_region":          regionResource(),
+			"ns1_datasource":      dataSourceResource(),
+			"ns1_datafeed":        dataFeedResource(),
+			"ns1_datafeed_value":  dataFeedValueResource(),
+			"ns1_monitoringjob":   monitoringJobResource(),
+			"ns1_health_check":    healthCheckResource(),
+			"ns1_notifylist":      notifyListResource(),
+			"ns1_user":            userResource(),
+			"ns1_apikey":          apikeyResource(),
+			"ns1_team":            teamResource(),
+			"ns1_team_membership": teamMembershipResource(),
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"ns1_datafeed":              dataFeedDataSource(),
+			"ns1_dnssec":                dnssecDataSource(),
+			"ns1_monitoringjob_history": monitoringJobHistoryDataSource(),
+			"ns1_monitoring_job_types":  monitoringJobTypesDataSource(),
+			"ns1_monitoring_regions":    monitoringRegionsDataSource(),
+			"ns1_permissions":           permissionsDataSource(),
 		},
 		ConfigureFunc: ns1Configure,
 	}
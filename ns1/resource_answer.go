@@ -11,7 +11,6 @@ import (
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
-	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
 
@@ -80,7 +79,7 @@ func resourceDataToAnswer(a *dns.Answer, resourceData *schema.ResourceData, old
 		a.RegionName = region
 	}
 	if meta != nil {
-		a.Meta = data.MetaFromMap(meta)
+		a.Meta = metaFromMap(meta)
 		errs := a.Meta.Validate()
 		if len(errs) > 0 {
 			return errJoin(append([]error{errors.New("found error/s in answer metadata")}, errs...), ",")
@@ -129,13 +128,13 @@ func findAnswer(resourceData *schema.ResourceData, record *dns.Record, old bool)
 			continue
 		}
 		// short-circuit if we only have the name of the answer
-		if answer.RegionName == "" && len(answer.Meta.StringMap()) == 0 {
+		if answer.RegionName == "" && len(metaToMap(answer.Meta)) == 0 {
 			return a, nil
 		}
 		if a.RegionName != answer.RegionName {
 			continue
 		}
-		if !reflect.DeepEqual(a.Meta.StringMap(), answer.Meta.StringMap()) {
+		if !reflect.DeepEqual(metaToMap(a.Meta), metaToMap(answer.Meta)) {
 			continue
 		}
 		return a, nil
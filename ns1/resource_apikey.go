@@ -1,6 +1,9 @@
 package ns1
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -18,35 +21,87 @@ func apikeyResource() *schema.Resource {
 			Required: true,
 		},
 		"key": {
+			Type:      schema.TypeString,
+			Computed:  true,
+			Sensitive: true,
+		},
+		"pgp_key": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ForceNew: true,
+		},
+		"key_fingerprint": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"encrypted_key": {
 			Type:     schema.TypeString,
 			Computed: true,
 		},
 		"teams": {
 			Type:     schema.TypeList,
 			Optional: true,
+			Computed: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 	}
 	s = addPermsSchema(s)
+	s = addIPWhitelistSchema(s)
+	s = addAPIKeyRotationSchema(s)
+	s["effective_permissions"] = effectivePermissionsSchema()
 	return &schema.Resource{
-		Schema: s,
-		Create: ApikeyCreate,
-		Read:   ApikeyRead,
-		Update: ApikeyUpdate,
-		Delete: ApikeyDelete,
+		Schema:        s,
+		SchemaVersion: 2,
+		MigrateState:  migratePermissionsState,
+		Create:        ApikeyCreate,
+		Read:          ApikeyRead,
+		Update:        ApikeyUpdate,
+		Delete:        ApikeyDelete,
 	}
 }
 
-func apikeyToResourceData(d *schema.ResourceData, k *account.APIKey) error {
+func apikeyToResourceData(d *schema.ResourceData, k *accountAPIKey, inherited permissionsMap) error {
 	d.SetId(k.ID)
 	d.Set("name", k.Name)
-	d.Set("key", k.Key)
+	// The key is only returned when the key is created.
+	if k.Key != "" {
+		if err := apikeyKeyToResourceData(d, k.Key); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting key for: %s, error: %s", k.ID, err)
+		}
+	}
 	d.Set("teams", k.TeamIDs)
-	permissionsToResourceData(d, k.Permissions)
-	return nil
+	if err := ipWhitelistToResourceData(d, k.ipWhitelist); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting ip_whitelist for: %s, error: %#v", k.ID, err)
+	}
+	if err := permissionsToResourceData(d, k.Permissions, inherited); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting permissions for: %s, error: %#v", k.ID, err)
+	}
+	if err := apikeyRotationToResourceData(d, time.Now()); err != nil {
+		return err
+	}
+	return effectivePermissionsToResourceData(d, k.Permissions)
 }
 
-func resourceDataToApikey(k *account.APIKey, d *schema.ResourceData) error {
+// apikeyKeyToResourceData sets the key, or if pgp_key is set, the key
+// encrypted with the PGP key.
+func apikeyKeyToResourceData(d *schema.ResourceData, key string) error {
+	v, ok := d.GetOk("pgp_key")
+	if !ok {
+		return d.Set("key", key)
+	}
+	entity, err := retrievePGPKey(v.(string))
+	if err != nil {
+		return err
+	}
+	encrypted, err := encryptValue(entity, key)
+	if err != nil {
+		return err
+	}
+	d.Set("key_fingerprint", pgpKeyFingerprint(entity))
+	return d.Set("encrypted_key", encrypted)
+}
+
+func resourceDataToApikey(k *accountAPIKey, d *schema.ResourceData) error {
 	k.ID = d.Id()
 	k.Name = d.Get("name").(string)
 	if v, ok := d.GetOk("teams"); ok {
@@ -59,51 +114,92 @@ func resourceDataToApikey(k *account.APIKey, d *schema.ResourceData) error {
 		k.TeamIDs = make([]string, 0)
 	}
 	k.Permissions = resourceDataToPermissions(d)
+	resourceDataToIPWhitelist(&k.ipWhitelist, d)
 	return nil
 }
 
 // ApikeyCreate creates ns1 API key
 func ApikeyCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	k := account.APIKey{}
+	k := accountAPIKey{}
 	if err := resourceDataToApikey(&k, d); err != nil {
 		return err
 	}
-	if _, err := client.APIKeys.Create(&k); err != nil {
+	// Check the PGP key before the key is created, since the key cannot be
+	// retrieved again.
+	if v, ok := d.GetOk("pgp_key"); ok {
+		if _, err := retrievePGPKey(v.(string)); err != nil {
+			return err
+		}
+	}
+	if _, err := newAccountService(client).CreateAPIKey(&k); err != nil {
 		return err
 	}
-	return apikeyToResourceData(d, &k)
+	d.Set("rotated_at", time.Now().UTC().Format(time.RFC3339))
+	inherited, err := teamsPermissions(client, k.TeamIDs)
+	if err != nil {
+		return err
+	}
+	return apikeyToResourceData(d, &k, inherited)
 }
 
 // ApikeyRead reads API key from ns1
 func ApikeyRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	k, _, err := client.APIKeys.Get(d.Id())
+	k, _, err := newAccountService(client).GetAPIKey(d.Id())
 	if err != nil {
 		return err
 	}
-	return apikeyToResourceData(d, k)
+	inherited, err := teamsPermissions(client, k.TeamIDs)
+	if err != nil {
+		return err
+	}
+	return apikeyToResourceData(d, k, inherited)
 }
 
-//ApikeyDelete deletes the given ns1 api key
+//ApikeyDelete deletes the given ns1 api key, and its previous key if it is
+// being rotated
 func ApikeyDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if err := revokePreviousAPIKey(client, d); err != nil {
+		return err
+	}
 	_, err := client.APIKeys.Delete(d.Id())
 	d.SetId("")
 	return err
 }
 
-//ApikeyUpdate updates the given api key in ns1
+//ApikeyUpdate updates the given api key in ns1, rotating it if keepers
+// changed or it is due for rotation
 func ApikeyUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	k := account.APIKey{
-		ID: d.Id(),
+	k := accountAPIKey{
+		APIKey: account.APIKey{
+			ID: d.Id(),
+		},
 	}
 	if err := resourceDataToApikey(&k, d); err != nil {
 		return err
 	}
-	if _, err := client.APIKeys.Update(&k); err != nil {
+	rotate, revoke, err := apikeyRotation(d, time.Now())
+	if err != nil {
+		return err
+	}
+	if revoke {
+		if err := revokePreviousAPIKey(client, d); err != nil {
+			return err
+		}
+	}
+	if rotate || d.HasChange("keepers") {
+		if err := rotateAPIKey(client, d, &k); err != nil {
+			return err
+		}
+	} else if _, err := newAccountService(client).UpdateAPIKey(&k); err != nil {
+		return err
+	}
+	inherited, err := teamsPermissions(client, k.TeamIDs)
+	if err != nil {
 		return err
 	}
-	return apikeyToResourceData(d, &k)
+	return apikeyToResourceData(d, &k, inherited)
 }
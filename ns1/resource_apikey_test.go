@@ -0,0 +1,387 @@
+package ns1
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccAPIKey_inheritedPermissions(t *testing.T) {
+	var apikey account.APIKey
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyInheritedPermissions(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &apikey),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "teams.#", "2"),
+					// Granted by the teams only
+					resource.TestCheckResourceAttr("ns1_apikey.k", "permissions.0.dns.#", "0"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "permissions.0.account.#", "0"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "effective_permissions.0.dns.0.view_zones", "true"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "effective_permissions.0.account.0.view_invoices", "true"),
+					// Set explicitly, and also granted by a team
+					resource.TestCheckResourceAttr("ns1_apikey.k", "permissions.0.monitoring.0.view_jobs", "true"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "effective_permissions.0.monitoring.0.view_jobs", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKey_ipWhitelist(t *testing.T) {
+	var apikey account.APIKey
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyIPWhitelist(rString, `["10.0.0.0/8", "192.0.2.1"]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &apikey),
+					testAccCheckAPIKeyIPWhitelist("ns1_apikey.k", []string{"10.0.0.0/8", "192.0.2.1"}, false),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "ip_whitelist.#", "2"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "ip_whitelist_strict", "false"),
+				),
+			},
+			{
+				Config: testAccAPIKeyIPWhitelist(rString, `["198.51.100.0/24", "2001:db8::/32"]`, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &apikey),
+					testAccCheckAPIKeyIPWhitelist("ns1_apikey.k", []string{"198.51.100.0/24", "2001:db8::/32"}, true),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "ip_whitelist.#", "2"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "ip_whitelist_strict", "true"),
+				),
+			},
+			{
+				Config: testAccAPIKeyIPWhitelist(rString, `[]`, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyIPWhitelist("ns1_apikey.k", []string{}, false),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "ip_whitelist.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKey_invalidIPWhitelist(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAPIKeyIPWhitelist(rString, `["10.0.0.0/33"]`, false),
+				ExpectError: regexp.MustCompile(`must be an IP address or a CIDR block`),
+			},
+			{
+				Config:      testAccAPIKeyIPWhitelist(rString, `["10.0.0.1/8"]`, false),
+				ExpectError: regexp.MustCompile(`did you mean 10.0.0.0/8`),
+			},
+		},
+	})
+}
+
+func TestAccAPIKey_rotation(t *testing.T) {
+	var first, second, third account.APIKey
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyRotation(rString, "1", 24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &first),
+					resource.TestCheckResourceAttrSet("ns1_apikey.k", "key"),
+					resource.TestCheckResourceAttrSet("ns1_apikey.k", "rotated_at"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "previous_id", ""),
+				),
+			},
+			// Changing keepers creates a successor, keeping the first key
+			// during the overlap window.
+			{
+				Config: testAccAPIKeyRotation(rString, "2", 24),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &second),
+					testAccCheckAPIKeyRotated(&first, &second),
+					testAccCheckAPIKeyPrevious("ns1_apikey.k", &first),
+					resource.TestCheckResourceAttrSet("ns1_apikey.k", "previous_key"),
+					resource.TestCheckResourceAttrSet("ns1_apikey.k", "previous_expires_at"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "rotation_due", "false"),
+				),
+			},
+			// Without an overlap window the previous key expires right away,
+			// and is revoked on the next apply.
+			{
+				Config: testAccAPIKeyRotation(rString, "3", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &third),
+					testAccCheckAPIKeyRotated(&second, &third),
+					testAccCheckAPIKeyPrevious("ns1_apikey.k", &second),
+					// Rotating again revokes a previous key still in its
+					// overlap window.
+					testAccCheckAPIKeyRevoked(&first),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAPIKeyRotation(rString, "3", 0),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyRevoked(&second),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "previous_id", ""),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "previous_key", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKey_pgpKey(t *testing.T) {
+	var apikey account.APIKey
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAPIKeyPGPKey(rString, testAccAPIKeyPGPPublicKey),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAPIKeyExists("ns1_apikey.k", &apikey),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "key", ""),
+					resource.TestCheckResourceAttrSet("ns1_apikey.k", "encrypted_key"),
+					resource.TestCheckResourceAttr("ns1_apikey.k", "key_fingerprint", testAccAPIKeyPGPFingerprint),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAPIKey_invalidPGPKey(t *testing.T) {
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAPIKeyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAPIKeyPGPKey(rString, "not a key"),
+				ExpectError: regexp.MustCompile(`pgp_key must be a base64 encoded public key`),
+			},
+		},
+	})
+}
+
+func testAccCheckAPIKeyDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ns1.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ns1_apikey" {
+			continue
+		}
+
+		k, _, err := client.APIKeys.Get(rs.Primary.Attributes["id"])
+		if err == nil {
+			return fmt.Errorf("API key still exists: %#v: %#v", err, k.Name)
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckAPIKeyExists(n string, apikey *account.APIKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		foundKey, _, err := client.APIKeys.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if foundKey.ID != rs.Primary.ID {
+			return fmt.Errorf("API key not found (%#v != %s)", foundKey, rs.Primary.ID)
+		}
+
+		*apikey = *foundKey
+
+		return nil
+	}
+}
+
+// testAccCheckAPIKeyRotated checks that next is a successor of prev.
+func testAccCheckAPIKeyRotated(prev, next *account.APIKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if prev.ID == next.ID {
+			return fmt.Errorf("API key %s was not rotated", prev.ID)
+		}
+		if prev.Name != next.Name {
+			return fmt.Errorf("Name: got: %s want: %s", next.Name, prev.Name)
+		}
+		return nil
+	}
+}
+
+// testAccCheckAPIKeyPrevious checks that the previous key of the resource is
+// prev, and that it still exists.
+func testAccCheckAPIKeyPrevious(n string, prev *account.APIKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.Attributes["previous_id"] != prev.ID {
+			return fmt.Errorf("previous_id: got: %s want: %s", rs.Primary.Attributes["previous_id"], prev.ID)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		if _, _, err := client.APIKeys.Get(prev.ID); err != nil {
+			return fmt.Errorf("Previous API key %s: %s", prev.ID, err)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAPIKeyRevoked checks that the key no longer exists.
+func testAccCheckAPIKeyRevoked(k *account.APIKey) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		if _, _, err := client.APIKeys.Get(k.ID); err == nil {
+			return fmt.Errorf("API key %s still exists", k.ID)
+		}
+
+		return nil
+	}
+}
+
+// testAccCheckAPIKeyIPWhitelist checks the IP whitelist of the API key as
+// returned by the API.
+func testAccCheckAPIKeyIPWhitelist(n string, expected []string, strict bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		k, _, err := newAccountService(client).GetAPIKey(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		got := append([]string{}, k.IPWhitelist...)
+		sort.Strings(got)
+		sort.Strings(expected)
+		if !reflect.DeepEqual(got, expected) {
+			return fmt.Errorf("IPWhitelist: got: %v want: %v", got, expected)
+		}
+		if k.IPWhitelistStrict != strict {
+			return fmt.Errorf("IPWhitelistStrict: got: %t want: %t", k.IPWhitelistStrict, strict)
+		}
+
+		return nil
+	}
+}
+
+func testAccAPIKeyInheritedPermissions(rString string) string {
+	return fmt.Sprintf(`resource "ns1_team" "dns" {
+  name = "terraform acc test dns team %s"
+  permissions {
+    dns {
+      view_zones = true
+    }
+    monitoring {
+      view_jobs = true
+    }
+  }
+}
+
+resource "ns1_team" "billing" {
+  name = "terraform acc test billing team %s"
+  permissions {
+    account {
+      view_invoices = true
+    }
+  }
+}
+
+resource "ns1_apikey" "k" {
+  name = "terraform acc test key %s"
+  teams = ["${ns1_team.dns.id}", "${ns1_team.billing.id}"]
+  permissions {
+    monitoring {
+      view_jobs = true
+    }
+  }
+}
+`, rString, rString, rString)
+}
+
+func testAccAPIKeyIPWhitelist(rString, whitelist string, strict bool) string {
+	return fmt.Sprintf(`resource "ns1_apikey" "k" {
+  name = "terraform acc test key %s"
+  ip_whitelist = %s
+  ip_whitelist_strict = %t
+}
+`, rString, whitelist, strict)
+}
+
+func testAccAPIKeyRotation(rString, version string, overlapHours int) string {
+	return fmt.Sprintf(`resource "ns1_apikey" "k" {
+  name = "terraform acc test key %s"
+  overlap_hours = %d
+  keepers {
+    version = "%s"
+  }
+}
+`, rString, overlapHours, version)
+}
+
+func testAccAPIKeyPGPKey(rString, pgpKey string) string {
+	return fmt.Sprintf(`resource "ns1_apikey" "k" {
+  name = "terraform acc test key %s"
+  pgp_key = "%s"
+}
+`, rString, pgpKey)
+}
+
+const testAccAPIKeyPGPFingerprint = "01cb0c5f6eeb835a1335d73215056572eb2d91f8"
+
+const testAccAPIKeyPGPPublicKey = "mI0EatUJnAEEAOxGY2/l/tPX4Q/rYuxwT7VHSJuBO97ipYmK5AOX5PcGGNndtLB8A7FfwiX4E8EF49zKnrv+AK3Kep3dbJPGF8/JU8k+eocNIC7n1qCTg6U/WcHNuPJMwaakNZjzECSudyOzB3Nm2EkI7pBwq+eieYMRqupss023WPoWevPW9hK5ABEBAAG0EnRlcnJhZm9ybSBhY2MgdGVzdIjOBBMBCgA4FiEEAcsMX27rg1oTNdcyFQVlcustkfgFAmrVCZwCGw8FCwkIBwIGFQoJCAsCBBYCAwECHgECF4AACgkQFQVlcustkfjXGwQAiGLLLrFNK7VW6OtEbERejC18BCqSUSC8S4yoxBfFa8JPgIORV0E/K/YBehDGckpeYPyIplnWME8qZcFeLjyVllU8Y4fxUYi3hrlLWK+NJpWAmOjobzQUZ7lB90rZG+hiK9SzboBUAtfPuSqaEH4TfCzEKAarezIV7qz8nRi+VMG4jQRq1QmcAQQAuhkIECx9P4kE2U6PsexH+cZ7YyhwVIVPktWcxjU1kf1X+ZbaZAM5dSyCQP8PRp5XHe691Rd7jpCFEM5VTPKAy5m35lkFll3R+ZR0YHgvz5O6tjAHv4cFgdU3dBAvpxeMtjP90XV3dhSyM6yO4lcJZsX1U+EZsR5w30zQKz2Qu3UAEQEAAYi2BBgBCgAgFiEEAcsMX27rg1oTNdcyFQVlcustkfgFAmrVCZwCGwwACgkQFQVlcustkfjszAQAyA4mQ+CfO8Da+h8IvllFfYZ/8UaKcHAIaF6ch8UPSDIYRWnMw5wedDpudGuMZ7nuBb0b9MJT//iQdYhAYhIiXD2u8nbcJU3sQ77pbhwZgymMLgu5c8Gtvl/sRV0FMJufPjJc/JmJSDwj3USGUx5cSxUdhp4oeVhhcyl0nc4I1TY="
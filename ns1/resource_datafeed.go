@@ -1,6 +1,9 @@
 package ns1
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -27,18 +30,75 @@ func dataFeedResource() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
+			// Computed
+			"data": {
+				Type:     schema.TypeMap,
+				Computed: true,
+			},
+			"destinations": dataFeedDestinationsSchema(),
+		},
+		Create:   DataFeedCreate,
+		Read:     DataFeedRead,
+		Update:   DataFeedUpdate,
+		Delete:   DataFeedDelete,
+		Importer: &schema.ResourceImporter{State: DataFeedStateFunc},
+	}
+}
+
+func dataFeedDestinationsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"record_id": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"type": {
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+			},
 		},
-		Create: DataFeedCreate,
-		Read:   DataFeedRead,
-		Update: DataFeedUpdate,
-		Delete: DataFeedDelete,
 	}
 }
 
 func dataFeedToResourceData(d *schema.ResourceData, f *data.Feed) {
 	d.SetId(f.ID)
+	if f.SourceID != "" {
+		d.Set("source_id", f.SourceID)
+	}
 	d.Set("name", f.Name)
-	d.Set("config", f.Config)
+	config := make(map[string]string)
+	for k, v := range f.Config {
+		if v != nil {
+			config[k] = fmt.Sprint(v)
+		}
+	}
+	d.Set("config", config)
+}
+
+// dataFeedStateToResourceData sets the current data and the destinations of
+// the feed, which are not returned when creating or updating it.
+func dataFeedStateToResourceData(d *schema.ResourceData, f *dataFeed) error {
+	d.Set("data", f.Data.StringMap())
+	destinations := make([]map[string]interface{}, len(f.Destinations))
+	for i, dest := range f.Destinations {
+		destinations[i] = map[string]interface{}{
+			"id":        dest.ID,
+			"record_id": dest.RecordID,
+			"type":      dest.Type,
+		}
+	}
+	if err := d.Set("destinations", destinations); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting destinations for: %s, error: %#v", f.Name, err)
+	}
+	return nil
 }
 
 func resourceDataToDataFeed(d *schema.ResourceData) *data.Feed {
@@ -57,18 +117,18 @@ func DataFeedCreate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 	dataFeedToResourceData(d, f)
-	return nil
+	return dataFeedStateToResourceData(d, &dataFeed{Feed: *f})
 }
 
 // DataFeedRead reads the datafeed for the given ID from ns1
 func DataFeedRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	f, _, err := client.DataFeeds.Get(d.Get("source_id").(string), d.Id())
+	f, _, err := newDataFeedService(client).Get(d.Get("source_id").(string), d.Id())
 	if err != nil {
 		return err
 	}
-	dataFeedToResourceData(d, f)
-	return nil
+	dataFeedToResourceData(d, &f.Feed)
+	return dataFeedStateToResourceData(d, f)
 }
 
 // DataFeedDelete delets the given datafeed from ns1
@@ -90,3 +150,16 @@ func DataFeedUpdate(d *schema.ResourceData, meta interface{}) error {
 	dataFeedToResourceData(d, f)
 	return nil
 }
+
+// DataFeedStateFunc imports a datafeed given as "source_id/feed_id"
+func DataFeedStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("Invalid datafeed specifier.  Expecting 1 slash (\"source_id/feed_id\"), got %d.", len(parts)-1)
+	}
+
+	d.SetId(parts[1])
+	d.Set("source_id", parts[0])
+
+	return []*schema.ResourceData{d}, nil
+}
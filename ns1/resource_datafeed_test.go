@@ -57,6 +57,38 @@ func TestAccDataFeed_updated(t *testing.T) {
 	})
 }
 
+func TestAccDataFeed_destinations(t *testing.T) {
+	var dataFeed data.Feed
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataFeedDestinations,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataFeedExists("ns1_datafeed.foobar", "ns1_datasource.api", &dataFeed, t),
+					resource.TestCheckResourceAttr("ns1_datafeed.foobar", "destinations.#", "0"),
+				),
+			},
+			resource.TestStep{
+				// The answer is connected to the feed after the feed was
+				// created, so its destinations are only seen on refresh.
+				Config: testAccDataFeedDestinations,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_datafeed.foobar", "destinations.#", "1"),
+					resource.TestCheckResourceAttr("ns1_datafeed.foobar", "destinations.0.type", "answer"),
+					resource.TestCheckResourceAttrSet("ns1_datafeed.foobar", "destinations.0.record_id"),
+					resource.TestCheckResourceAttrPair(
+						"ns1_datafeed.foobar", "source_id",
+						"ns1_datasource.api", "id",
+					),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckDataFeedExists(n string, dsrc string, dataFeed *data.Feed, t *testing.T) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -168,3 +200,35 @@ resource "ns1_datafeed" "foobar" {
     label = "exampledc3"
   }
 }`
+
+const testAccDataFeedDestinations = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = "${ns1_datasource.api.id}"
+  config {
+    label = "exampledc2"
+  }
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-datafeed-test.io"
+}
+
+resource "ns1_record" "it" {
+  zone   = "${ns1_zone.test.zone}"
+  domain = "test.${ns1_zone.test.zone}"
+  type   = "A"
+
+  answers {
+    answer = "1.2.3.4"
+
+    meta {
+      up = "{\"feed\":\"${ns1_datafeed.foobar.id}\"}"
+    }
+  }
+}`
@@ -0,0 +1,147 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+)
+
+// publishSourceType is the type of the data source that accepts data
+// published through the NS1 API.
+const publishSourceType = "nsone_v1"
+
+func dataFeedValueResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"source_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"feed_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"data": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				DiffSuppressFunc: dataFeedValueDiffSuppress,
+			},
+		},
+		Create: DataFeedValueCreate,
+		Read:   DataFeedValueRead,
+		Update: DataFeedValueUpdate,
+		Delete: DataFeedValueDelete,
+	}
+}
+
+// parseDataFeedValue converts a configured value to the type the data API
+// expects: booleans for flags such as up, numbers for metrics such as
+// weight, and strings otherwise.
+func parseDataFeedValue(v string) interface{} {
+	switch v {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if f, err := strconv.ParseFloat(v, 64); err == nil {
+		return f
+	}
+	return v
+}
+
+// dataFeedValueDiffSuppress ignores differences in how values are written,
+// e.g. between true and 1 for up.
+func dataFeedValueDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if old == "" || new == "" {
+		return false
+	}
+	return data.FormatInterface(parseDataFeedValue(old)) == data.FormatInterface(parseDataFeedValue(new))
+}
+
+// dataFeedLabel returns the label the feed is published under, after
+// checking that its source accepts published data.
+func dataFeedLabel(client *ns1.Client, sourceID, feedID string) (string, error) {
+	s, _, err := client.DataSources.Get(sourceID)
+	if err != nil {
+		return "", err
+	}
+	if s.Type != publishSourceType {
+		return "", fmt.Errorf("cannot publish to datasource %s of sourcetype %q, expecting %q", sourceID, s.Type, publishSourceType)
+	}
+	f, _, err := client.DataFeeds.Get(sourceID, feedID)
+	if err != nil {
+		return "", err
+	}
+	label, ok := f.Config["label"].(string)
+	if !ok || label == "" {
+		return "", fmt.Errorf("feed %s has no label to publish to", feedID)
+	}
+	return label, nil
+}
+
+func publishDataFeedValue(client *ns1.Client, d *schema.ResourceData) error {
+	sourceID := d.Get("source_id").(string)
+	feedID := d.Get("feed_id").(string)
+	label, err := dataFeedLabel(client, sourceID, feedID)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	for k, v := range d.Get("data").(map[string]interface{}) {
+		values[k] = parseDataFeedValue(v.(string))
+	}
+	_, err = client.DataSources.Publish(sourceID, map[string]interface{}{label: values})
+	return err
+}
+
+// DataFeedValueCreate publishes data to an ns1 datafeed
+func DataFeedValueCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := publishDataFeedValue(client, d); err != nil {
+		return err
+	}
+	d.SetId(d.Get("feed_id").(string))
+	return nil
+}
+
+// DataFeedValueRead reads the published data of an ns1 datafeed
+func DataFeedValueRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	f, _, err := client.DataFeeds.Get(d.Get("source_id").(string), d.Id())
+	if err != nil {
+		return err
+	}
+	// Only the published keys are managed, others may be published by
+	// other means.
+	current := f.Data.StringMap()
+	values := make(map[string]interface{})
+	for k := range d.Get("data").(map[string]interface{}) {
+		if v, ok := current[k]; ok {
+			values[k] = v
+		}
+	}
+	d.Set("data", values)
+	return nil
+}
+
+// DataFeedValueUpdate publishes the changed data to an ns1 datafeed
+func DataFeedValueUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	return publishDataFeedValue(client, d)
+}
+
+// DataFeedValueDelete stops managing the data of an ns1 datafeed. The data
+// API has no way to unpublish data, so the last published values stay.
+func DataFeedValueDelete(d *schema.ResourceData, meta interface{}) error {
+	log.Printf("[INFO] Leaving the data published to feed %s in place", d.Id())
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,131 @@
+package ns1
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+func TestAccDataFeedValue_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataFeedValueBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataFeedValue("ns1_datafeed_value.it", "up", "1"),
+					testAccCheckDataFeedValue("ns1_datafeed_value.it", "weight", "10"),
+				),
+			},
+			{
+				Config: testAccDataFeedValueDrained,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataFeedValue("ns1_datafeed_value.it", "up", "0"),
+					testAccCheckDataFeedValue("ns1_datafeed_value.it", "weight", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataFeedValue_noLabel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataFeedDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDataFeedValueNoLabel,
+				ExpectError: regexp.MustCompile(`feed .* has no label to publish to`),
+			},
+		},
+	})
+}
+
+func testAccCheckDataFeedValue(n, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		f, _, err := client.DataFeeds.Get(rs.Primary.Attributes["source_id"], rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if v := f.Data.StringMap()[key]; v != expected {
+			return fmt.Errorf("Data[%s]: got: %#v want: %#v", key, v, expected)
+		}
+
+		return nil
+	}
+}
+
+// testAccDataFeedValueConfig returns a config publishing the given data to
+// a feed of a nsone_v1 datasource.
+func testAccDataFeedValueConfig(values string) string {
+	return fmt.Sprintf(`
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = "${ns1_datasource.api.id}"
+  config {
+    label = "exampledc2"
+  }
+}
+
+resource "ns1_datafeed_value" "it" {
+  source_id = "${ns1_datasource.api.id}"
+  feed_id   = "${ns1_datafeed.foobar.id}"
+
+  data {
+%s
+  }
+}
+`, values)
+}
+
+var testAccDataFeedValueBasic = testAccDataFeedValueConfig(`
+    up     = "true"
+    weight = "10"
+`)
+
+var testAccDataFeedValueDrained = testAccDataFeedValueConfig(`
+    up     = "false"
+    weight = "0"
+`)
+
+const testAccDataFeedValueNoLabel = `
+resource "ns1_datasource" "api" {
+  name = "terraform test"
+  sourcetype = "nsone_v1"
+}
+
+resource "ns1_datafeed" "foobar" {
+  name = "terraform test"
+  source_id = "${ns1_datasource.api.id}"
+}
+
+resource "ns1_datafeed_value" "it" {
+  source_id = "${ns1_datasource.api.id}"
+  feed_id   = "${ns1_datafeed.foobar.id}"
+
+  data {
+    up = "true"
+  }
+}
+`
@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -9,7 +11,7 @@ import (
 
 func dataSourceResource() *schema.Resource {
 	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
+		Schema: addDataSourceConfigSchema(map[string]*schema.Schema{
 			"id": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -27,7 +29,7 @@ func dataSourceResource() *schema.Resource {
 				Type:     schema.TypeMap,
 				Optional: true,
 			},
-		},
+		}),
 		Create: DataSourceCreate,
 		Read:   DataSourceRead,
 		Update: DataSourceUpdate,
@@ -35,23 +37,38 @@ func dataSourceResource() *schema.Resource {
 	}
 }
 
-func dataSourceToResourceData(d *schema.ResourceData, s *data.Source) {
+func dataSourceToResourceData(d *schema.ResourceData, s *data.Source) error {
 	d.SetId(s.ID)
 	d.Set("name", s.Name)
 	d.Set("sourcetype", s.Type)
-	d.Set("config", s.Config)
+	if err := dataSourceConfigToResourceData(d, s); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting config for: %s, error: %#v", s.Name, err)
+	}
+	return nil
+}
+
+func resourceDataToDataSource(d *schema.ResourceData) (*data.Source, error) {
+	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
+	s.ID = d.Id()
+	config, err := resourceDataToDataSourceConfig(d, s.Type)
+	if err != nil {
+		return nil, err
+	}
+	s.Config = config
+	return s, nil
 }
 
 // DataSourceCreate creates an ns1 datasource
 func DataSourceCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
-	s.Config = d.Get("config").(map[string]interface{})
+	s, err := resourceDataToDataSource(d)
+	if err != nil {
+		return err
+	}
 	if _, err := client.DataSources.Create(s); err != nil {
 		return err
 	}
-	dataSourceToResourceData(d, s)
-	return nil
+	return dataSourceToResourceData(d, s)
 }
 
 // DataSourceRead fetches info for the given datasource from ns1
@@ -61,8 +78,7 @@ func DataSourceRead(d *schema.ResourceData, meta interface{}) error {
 	if err != nil {
 		return err
 	}
-	dataSourceToResourceData(d, s)
-	return nil
+	return dataSourceToResourceData(d, s)
 }
 
 // DataSourceDelete deteltes the given datasource from ns1
@@ -76,11 +92,12 @@ func DataSourceDelete(d *schema.ResourceData, meta interface{}) error {
 // DataSourceUpdate updates the datasource with given parameters
 func DataSourceUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	s := data.NewSource(d.Get("name").(string), d.Get("sourcetype").(string))
-	s.ID = d.Id()
+	s, err := resourceDataToDataSource(d)
+	if err != nil {
+		return err
+	}
 	if _, err := client.DataSources.Update(s); err != nil {
 		return err
 	}
-	dataSourceToResourceData(d, s)
-	return nil
+	return dataSourceToResourceData(d, s)
 }
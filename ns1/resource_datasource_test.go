@@ -2,6 +2,7 @@ package ns1
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -57,6 +58,60 @@ func TestAccDataSource_updated(t *testing.T) {
 	})
 }
 
+func TestAccDataSource_configNotSupported(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccDataSourceConfigNotSupported,
+				ExpectError: regexp.MustCompile(`config cannot be used with sourcetype "nsone_v1"`),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_config(t *testing.T) {
+	var dataSource data.Source
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccDataSourceConfig("terraform"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("ns1_datasource.foobar", &dataSource),
+					testAccCheckDataSourceConfig(&dataSource, "username", "terraform"),
+				),
+			},
+			// Config changes are sent on update.
+			resource.TestStep{
+				Config: testAccDataSourceConfig("terraform-updated"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckDataSourceExists("ns1_datasource.foobar", &dataSource),
+					testAccCheckDataSourceConfig(&dataSource, "username", "terraform-updated"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSource_configMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDataSourceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccDataSourceConfigMismatch,
+				ExpectError: regexp.MustCompile(`datadog_config cannot be used with sourcetype "pingdom"`),
+			},
+		},
+	})
+}
+
 func testAccCheckDataSourceExists(n string, dataSource *data.Source) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -127,6 +182,16 @@ func testAccCheckDataSourceType(dataSource *data.Source, expected string) resour
 	}
 }
 
+func testAccCheckDataSourceConfig(dataSource *data.Source, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if dataSource.Config[key] != expected {
+			return fmt.Errorf("Config[%q]: got: %#v want: %#v", key, dataSource.Config[key], expected)
+		}
+
+		return nil
+	}
+}
+
 const testAccDataSourceBasic = `
 resource "ns1_datasource" "foobar" {
 	name = "terraform test"
@@ -138,3 +203,35 @@ resource "ns1_datasource" "foobar" {
 	name = "terraform test"
 	sourcetype = "nsone_monitoring"
 }`
+
+const testAccDataSourceConfigNotSupported = `
+resource "ns1_datasource" "foobar" {
+	name = "terraform test"
+	sourcetype = "nsone_v1"
+	config = {
+		label = "dc1"
+	}
+}`
+
+func testAccDataSourceConfig(username string) string {
+	return fmt.Sprintf(`
+resource "ns1_datasource" "foobar" {
+	name = "terraform test"
+	sourcetype = "pingdom"
+	pingdom_config {
+		username = "%s"
+		password = "terraform-password"
+		app_key = "terraform-app-key"
+	}
+}`, username)
+}
+
+const testAccDataSourceConfigMismatch = `
+resource "ns1_datasource" "foobar" {
+	name = "terraform test"
+	sourcetype = "pingdom"
+	datadog_config {
+		api_key = "terraform-api-key"
+		application_key = "terraform-application-key"
+	}
+}`
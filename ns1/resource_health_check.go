@@ -0,0 +1,237 @@
+package ns1
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
+)
+
+// monitoringSourceType is the type of the data source that publishes the
+// status of monitoring jobs to their feeds.
+const monitoringSourceType = "nsone_monitoring"
+
+func healthCheckResource() *schema.Resource {
+	s := monitoringJobSchema()
+	// Optional
+	s["source_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+		ForceNew: true,
+	}
+	s["feed_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+	// Computed
+	s["feed_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["up_meta"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+	s["source_created"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Computed: true,
+	}
+	return &schema.Resource{
+		Schema: s,
+		Create: HealthCheckCreate,
+		Read:   HealthCheckRead,
+		Update: HealthCheckUpdate,
+		Delete: HealthCheckDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
+	}
+}
+
+// ensureMonitoringSource returns the id of the accounts' monitoring data
+// source, creating it if there is none. created reports whether it was.
+func ensureMonitoringSource(client *ns1.Client) (id string, created bool, err error) {
+	sources, _, err := client.DataSources.List()
+	if err != nil {
+		return "", false, err
+	}
+	for _, s := range sources {
+		if s.Type == monitoringSourceType {
+			return s.ID, false, nil
+		}
+	}
+	s := data.NewSource("NS1 Monitoring", monitoringSourceType)
+	if _, err := client.DataSources.Create(s); err != nil {
+		return "", false, err
+	}
+	return s.ID, true, nil
+}
+
+// deleteCreatedMonitoringSource deletes the monitoring data source if it was
+// created by a health check that failed to be created, since it is not in the
+// state yet.
+func deleteCreatedMonitoringSource(client *ns1.Client, sourceID string, created bool) {
+	if !created {
+		return
+	}
+	if _, err := client.DataSources.Delete(sourceID); err != nil {
+		log.Printf("[WARN] Failed to delete data source %s: %s", sourceID, err)
+	}
+}
+
+func healthCheckFeedToResourceData(d *schema.ResourceData, sourceID string, f *data.Feed) error {
+	d.Set("source_id", sourceID)
+	d.Set("feed_id", f.ID)
+	// The feed is named after the job unless feed_name is set.
+	if d.Get("feed_name").(string) != "" {
+		d.Set("feed_name", f.Name)
+	}
+	up, err := json.Marshal(data.FeedPtr{FeedID: f.ID})
+	if err != nil {
+		return err
+	}
+	d.Set("up_meta", string(up))
+	return nil
+}
+
+// HealthCheckCreate creates the monitoring job of the health check, the
+// monitoring data source if needed, and a feed of the jobs' status
+func HealthCheckCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	j := monitor.Job{}
+	if err := resourceDataToMonitoringJob(&j, d); err != nil {
+		return err
+	}
+	if err := checkMonitoringJob(client, &j); err != nil {
+		return err
+	}
+
+	sourceID := d.Get("source_id").(string)
+	created := false
+	if sourceID == "" {
+		var err error
+		if sourceID, created, err = ensureMonitoringSource(client); err != nil {
+			return err
+		}
+	}
+
+	if _, err := client.Jobs.Create(&j); err != nil {
+		deleteCreatedMonitoringSource(client, sourceID, created)
+		return err
+	}
+
+	feedName := d.Get("feed_name").(string)
+	if feedName == "" {
+		feedName = j.Name
+	}
+	f := data.NewFeed(feedName, data.Config{"jobid": j.ID})
+	if _, err := client.DataFeeds.Create(sourceID, f); err != nil {
+		// Don't leave the job behind, it is not in the state yet.
+		if _, derr := client.Jobs.Delete(j.ID); derr != nil {
+			log.Printf("[WARN] Failed to delete monitoring job %s: %s", j.ID, derr)
+		}
+		deleteCreatedMonitoringSource(client, sourceID, created)
+		return err
+	}
+
+	if err := monitoringJobToResourceData(d, &j); err != nil {
+		return err
+	}
+	d.Set("source_created", created)
+	if err := healthCheckFeedToResourceData(d, sourceID, f); err != nil {
+		return err
+	}
+	return waitForMonitoringJobStatus(client, d, d.Timeout(schema.TimeoutCreate))
+}
+
+// HealthCheckRead reads the monitoring job and feed of the health check from ns1
+func HealthCheckRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	j, _, err := client.Jobs.Get(d.Id())
+	if err != nil {
+		return err
+	}
+	if err := monitoringJobToResourceData(d, j); err != nil {
+		return err
+	}
+	sourceID := d.Get("source_id").(string)
+	f, _, err := client.DataFeeds.Get(sourceID, d.Get("feed_id").(string))
+	if err != nil {
+		return err
+	}
+	return healthCheckFeedToResourceData(d, sourceID, f)
+}
+
+// HealthCheckUpdate updates the monitoring job and feed of the health check
+func HealthCheckUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	j := monitor.Job{
+		ID: d.Id(),
+	}
+	if err := resourceDataToMonitoringJob(&j, d); err != nil {
+		return err
+	}
+	if err := checkMonitoringJob(client, &j); err != nil {
+		return err
+	}
+	if _, err := client.Jobs.Update(&j); err != nil {
+		return err
+	}
+	if err := monitoringJobToResourceData(d, &j); err != nil {
+		return err
+	}
+
+	sourceID := d.Get("source_id").(string)
+	if d.HasChange("feed_name") || d.HasChange("name") {
+		feedName := d.Get("feed_name").(string)
+		if feedName == "" {
+			feedName = j.Name
+		}
+		f := data.NewFeed(feedName, data.Config{"jobid": j.ID})
+		f.ID = d.Get("feed_id").(string)
+		if _, err := client.DataFeeds.Update(sourceID, f); err != nil {
+			return err
+		}
+		if err := healthCheckFeedToResourceData(d, sourceID, f); err != nil {
+			return err
+		}
+	}
+	return waitForMonitoringJobStatus(client, d, d.Timeout(schema.TimeoutUpdate))
+}
+
+// HealthCheckDelete deletes the feed and monitoring job of the health check,
+// and the monitoring data source if it was created for the health check and
+// has no feeds left
+func HealthCheckDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	sourceID := d.Get("source_id").(string)
+	if _, err := client.DataFeeds.Delete(sourceID, d.Get("feed_id").(string)); err != nil {
+		return fmt.Errorf("failed to delete feed of health check %s: %s", d.Id(), err)
+	}
+	if _, err := client.Jobs.Delete(d.Id()); err != nil {
+		return err
+	}
+	if d.Get("source_created").(bool) {
+		feeds, _, err := client.DataFeeds.List(sourceID)
+		if err != nil {
+			return err
+		}
+		if len(feeds) == 0 {
+			if _, err := client.DataSources.Delete(sourceID); err != nil {
+				return err
+			}
+		} else {
+			log.Printf("[INFO] Keeping data source %s, it still has %d feeds", sourceID, len(feeds))
+		}
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,199 @@
+package ns1
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestAccHealthCheck_basic(t *testing.T) {
+	var feed data.Feed
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccHealthCheckBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHealthCheckFeed("ns1_health_check.it", &feed),
+					testAccCheckDataFeedName(&feed, "terraform test"),
+					testAccCheckHealthCheckFeedJob("ns1_health_check.it", &feed),
+					resource.TestCheckResourceAttrSet("ns1_health_check.it", "source_id"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccHealthCheckUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHealthCheckFeed("ns1_health_check.it", &feed),
+					testAccCheckDataFeedName(&feed, "terraform test feed"),
+					testAccCheckHealthCheckFeedJob("ns1_health_check.it", &feed),
+				),
+			},
+		},
+	})
+}
+
+func TestAccHealthCheck_answerUp(t *testing.T) {
+	var feed data.Feed
+	var record dns.Record
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckHealthCheckDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccHealthCheckAnswerUp,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckHealthCheckFeed("ns1_health_check.it", &feed),
+					testAccCheckRecordExists("ns1_record.it", &record),
+					testAccCheckRecordAnswerUpFeed(&record, &feed),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckRecordAnswerUpFeed(r *dns.Record, feed *data.Feed) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		up := r.Answers[0].Meta.Up
+		// Meta values are decoded from JSON into generic values.
+		m, ok := up.(map[string]interface{})
+		if !ok || m["feed"] != feed.ID {
+			return fmt.Errorf("r.Answers[0].Meta.Up: got: %#v want feed: %s", up, feed.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckHealthCheckFeed(n string, feed *data.Feed) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		p := rs.Primary
+		if p.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		foundFeed, _, err := client.DataFeeds.Get(p.Attributes["source_id"], p.Attributes["feed_id"])
+		if err != nil {
+			return err
+		}
+
+		want := fmt.Sprintf(`{"feed":"%s"}`, foundFeed.ID)
+		if p.Attributes["up_meta"] != want {
+			return fmt.Errorf("up_meta: got: %#v want: %#v", p.Attributes["up_meta"], want)
+		}
+
+		*feed = *foundFeed
+
+		return nil
+	}
+}
+
+func testAccCheckHealthCheckFeedJob(n string, feed *data.Feed) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[n]
+		if feed.Config["jobid"] != rs.Primary.ID {
+			return fmt.Errorf("jobid: got: %#v want: %#v", feed.Config["jobid"], rs.Primary.ID)
+		}
+		return nil
+	}
+}
+
+func testAccCheckHealthCheckDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*ns1.Client)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "ns1_health_check" {
+			continue
+		}
+
+		if _, _, err := client.Jobs.Get(rs.Primary.ID); err == nil {
+			return fmt.Errorf("Monitoring Job still exists: %s", rs.Primary.ID)
+		}
+
+		feed, _, err := client.DataFeeds.Get(rs.Primary.Attributes["source_id"], rs.Primary.Attributes["feed_id"])
+		if err == nil {
+			return fmt.Errorf("Data Feed still exists: %#v", feed)
+		}
+	}
+
+	return nil
+}
+
+const testAccHealthCheckBasic = `
+resource "ns1_health_check" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+`
+
+const testAccHealthCheckUpdated = `
+resource "ns1_health_check" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga", "sjc"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+
+  feed_name = "terraform test feed"
+}
+`
+
+const testAccHealthCheckAnswerUp = `
+resource "ns1_zone" "it" {
+  zone = "terraform-health-check-test.io"
+}
+
+resource "ns1_health_check" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+
+resource "ns1_record" "it" {
+  zone   = "${ns1_zone.it.zone}"
+  domain = "www.${ns1_zone.it.zone}"
+  type   = "A"
+
+  answers {
+    answer = "1.2.3.4"
+
+    meta {
+      up = "${ns1_health_check.it.up_meta}"
+    }
+  }
+}
+`
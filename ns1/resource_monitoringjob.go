@@ -2,121 +2,206 @@ package ns1
 
 import (
 	"fmt"
+	"log"
 	"regexp"
+	"sort"
 	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/monitor"
 )
 
-func monitoringJobResource() *schema.Resource {
-	return &schema.Resource{
-		Schema: map[string]*schema.Schema{
-			// Required
-			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+var jobStatusStringEnum *StringEnum = NewStringEnum([]string{
+	"up",
+	"down",
+})
+
+var jobPolicyStringEnum *StringEnum = NewStringEnum([]string{
+	"all",
+	"one",
+	"quorum",
+})
+
+var regionScopeStringEnum *StringEnum = NewStringEnum([]string{
+	"fixed",
+	"all",
+})
+
+// monitoringJobSchema returns the schema of a monitoring job, shared by the
+// ns1_monitoringjob and ns1_health_check resources.
+func monitoringJobSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		// Required
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"job_type": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"frequency": {
+			Type:     schema.TypeInt,
+			Required: true,
+		},
+		// Optional
+		"region_scope": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "fixed",
+			ValidateFunc: regionScopeStringEnum.ValidateFunc,
+		},
+		"regions": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Computed: true,
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
 			},
-			"job_type": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+			DiffSuppressFunc: monitoringJobRegionsDiffSuppress,
+		},
+		"config": {
+			Type:     schema.TypeMap,
+			Optional: true,
+		},
+		"active": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  true,
+		},
+		"rapid_recheck": {
+			Type:     schema.TypeBool,
+			Optional: true,
+			Default:  false,
+		},
+		"policy": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Default:  "quorum",
+			ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
+				value := v.(string)
+				if !regexp.MustCompile(`^(all|one|quorum)$`).MatchString(value) {
+					es = append(es, fmt.Errorf(
+						"only all, one, quorum allowed in %q", k))
+				}
+				return
 			},
-			"regions": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+		},
+		"notes": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"notify_delay": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"notify_repeat": {
+			Type:     schema.TypeInt,
+			Optional: true,
+		},
+		"notify_failback": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"notify_regional": {
+			Type:     schema.TypeBool,
+			Optional: true,
+		},
+		"notify_list": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"rules": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"value": {
+						Type:             schema.TypeString,
+						Required:         true,
+						DiffSuppressFunc: monitoringJobRuleValueDiffSuppress,
+					},
+					"comparison": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
+					"key": {
+						Type:     schema.TypeString,
+						Required: true,
+					},
 				},
 			},
-			"frequency": {
-				Type:     schema.TypeInt,
-				Required: true,
-			},
-			"config": {
-				Type:     schema.TypeMap,
-				Required: true,
-			},
-			// Optional
-			"active": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  true,
-			},
-			"rapid_recheck": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-			},
-			"policy": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Default:  "quorum",
-				ValidateFunc: func(v interface{}, k string) (ws []string, es []error) {
-					value := v.(string)
-					if !regexp.MustCompile(`^(all|one|quorum)$`).MatchString(value) {
-						es = append(es, fmt.Errorf(
-							"only all, one, quorum allowed in %q", k))
-					}
-					return
+		},
+		"wait_for_status": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"status": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Default:      "up",
+						ValidateFunc: jobStatusStringEnum.ValidateFunc,
+					},
+					"policy": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						ValidateFunc: jobPolicyStringEnum.ValidateFunc,
+					},
 				},
 			},
-			"notes": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"notify_delay": {
-				Type:     schema.TypeInt,
-				Optional: true,
-			},
-			"notify_repeat": {
-				Type:     schema.TypeInt,
-				Optional: true,
-			},
-			"notify_failback": {
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
-			"notify_regional": {
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
-			"notify_list": {
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"rules": {
-				Type:     schema.TypeList,
-				Optional: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"value": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"comparison": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
-						"key": {
-							Type:     schema.TypeString,
-							Required: true,
-						},
+		},
+		// Computed
+		"id": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+		"status": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"region": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"status": {
+						Type:     schema.TypeString,
+						Computed: true,
+					},
+					"since": {
+						Type:     schema.TypeInt,
+						Computed: true,
 					},
 				},
 			},
-			// Computed
-			"id": {
-				Type:     schema.TypeString,
-				Computed: true,
-			},
 		},
+		"global_status": {
+			Type:     schema.TypeString,
+			Computed: true,
+		},
+	}
+	s = addMonitoringJobConfigSchema(s)
+	return addMonitoringJobMaintenanceSchema(s)
+}
+
+func monitoringJobResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: monitoringJobSchema(),
 		Create: MonitoringJobCreate,
 		Read:   MonitoringJobRead,
 		Update: MonitoringJobUpdate,
 		Delete: MonitoringJobDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 	}
 }
 
@@ -124,30 +209,12 @@ func monitoringJobToResourceData(d *schema.ResourceData, r *monitor.Job) error {
 	d.SetId(r.ID)
 	d.Set("name", r.Name)
 	d.Set("job_type", r.Type)
-	d.Set("active", r.Active)
+	d.Set("region_scope", r.RegionScope)
 	d.Set("regions", r.Regions)
 	d.Set("frequency", r.Frequency)
 	d.Set("rapid_recheck", r.RapidRecheck)
-	config := make(map[string]string)
-	for k, v := range r.Config {
-		if k == "ssl" {
-			if v.(bool) {
-				config[k] = "1"
-			} else {
-				config[k] = "0"
-			}
-		} else {
-			switch t := v.(type) {
-			case string:
-				config[k] = t
-			case float64:
-				config[k] = strconv.FormatFloat(t, 'f', -1, 64)
-			}
-		}
-	}
-	err := d.Set("config", config)
-	if err != nil {
-		panic(fmt.Errorf("[DEBUG] Error setting Config error: %#v %#v", r.Config, err))
+	if err := monitoringJobConfigToResourceData(d, r); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting Config error: %#v %#v", r.Config, err)
 	}
 	d.Set("policy", r.Policy)
 	d.Set("notes", r.Notes)
@@ -156,12 +223,17 @@ func monitoringJobToResourceData(d *schema.ResourceData, r *monitor.Job) error {
 	d.Set("notify_repeat", r.NotifyRepeat)
 	d.Set("notify_regional", r.NotifyRegional)
 	d.Set("notify_failback", r.NotifyFailback)
-	d.Set("notify_list", r.NotifyListID)
+	if err := monitoringJobMaintenanceToResourceData(d, r, time.Now()); err != nil {
+		return err
+	}
+	if err := monitoringJobStatusToResourceData(d, r); err != nil {
+		return err
+	}
 	if len(r.Rules) > 0 {
 		rules := make([]map[string]interface{}, len(r.Rules))
 		for i, r := range r.Rules {
 			m := make(map[string]interface{})
-			m["value"] = r.Value
+			m["value"] = monitoringJobRuleValueString(r.Value)
 			m["comparison"] = r.Comparison
 			m["key"] = r.Key
 			rules[i] = m
@@ -171,19 +243,65 @@ func monitoringJobToResourceData(d *schema.ResourceData, r *monitor.Job) error {
 	return nil
 }
 
+// monitoringJobRegionsDiffSuppress ignores the regions of jobs that are not
+// scoped to fixed regions, since NS1 assigns those jobs to all of its
+// monitoring regions, including ones added later.
+func monitoringJobRegionsDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	return d.Get("region_scope").(string) != "fixed"
+}
+
+// monitoringGlobalStatusKey is the key of the jobs' status that holds the
+// status across all regions, as determined by the jobs' policy.
+const monitoringGlobalStatusKey = "global"
+
+func monitoringJobStatusToResourceData(d *schema.ResourceData, j *monitor.Job) error {
+	regions := make([]string, 0, len(j.Status))
+	for region, status := range j.Status {
+		if region != monitoringGlobalStatusKey && status != nil {
+			regions = append(regions, region)
+		}
+	}
+	sort.Strings(regions)
+	status := make([]map[string]interface{}, len(regions))
+	for i, region := range regions {
+		status[i] = map[string]interface{}{
+			"region": region,
+			"status": j.Status[region].Status,
+			"since":  j.Status[region].Since,
+		}
+	}
+	if err := d.Set("status", status); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting status for: %s, error: %#v", j.Name, err)
+	}
+	if global, ok := j.Status[monitoringGlobalStatusKey]; ok && global != nil {
+		d.Set("global_status", global.Status)
+	} else {
+		d.Set("global_status", "")
+	}
+	return nil
+}
+
 func resourceDataToMonitoringJob(r *monitor.Job, d *schema.ResourceData) error {
 	r.ID = d.Id()
 	r.Name = d.Get("name").(string)
 	r.Type = d.Get("job_type").(string)
 	r.Active = d.Get("active").(bool)
-	rawRegions := d.Get("regions").([]interface{})
-	r.Regions = make([]string, len(rawRegions))
-	for i, v := range rawRegions {
-		r.Regions[i] = v.(string)
+	r.RegionScope = d.Get("region_scope").(string)
+	r.Regions = []string{}
+	if r.RegionScope == "fixed" {
+		rawRegions := d.Get("regions").([]interface{})
+		if len(rawRegions) == 0 {
+			return fmt.Errorf("regions must be set when region_scope is \"fixed\"")
+		}
+		r.Regions = make([]string, len(rawRegions))
+		for i, v := range rawRegions {
+			r.Regions[i] = v.(string)
+		}
 	}
 	r.Frequency = d.Get("frequency").(int)
 	r.RapidRecheck = d.Get("rapid_recheck").(bool)
-	var rawRules []interface{}
+	// Rule values are kept as strings here and converted to the type of
+	// their metric by checkMonitoringJob.
 	if rawRules := d.Get("rules"); rawRules != nil {
 		r.Rules = make([]*monitor.Rule, len(rawRules.([]interface{})))
 		for i, v := range rawRules.([]interface{}) {
@@ -197,37 +315,9 @@ func resourceDataToMonitoringJob(r *monitor.Job, d *schema.ResourceData) error {
 	} else {
 		r.Rules = make([]*monitor.Rule, 0)
 	}
-	for i, v := range rawRules {
-		rule := v.(map[string]interface{})
-		r.Rules[i] = &monitor.Rule{
-			Comparison: rule["comparison"].(string),
-			Key:        rule["key"].(string),
-		}
-		value := rule["value"].(string)
-		if i, err := strconv.Atoi(value); err == nil {
-			r.Rules[i].Value = i
-		} else {
-			r.Rules[i].Value = value
-		}
-	}
-	config := make(map[string]interface{})
-	if rawConfig := d.Get("config"); rawConfig != nil {
-		for k, v := range rawConfig.(map[string]interface{}) {
-			if k == "ssl" {
-				if v.(string) == "1" {
-					config[k] = true
-				}
-			} else {
-				if i, err := strconv.Atoi(v.(string)); err == nil {
-					config[k] = i
-				} else {
-					config[k] = v
-				}
-			}
-		}
+	if err := resourceDataToMonitoringJobConfig(r, d); err != nil {
+		return err
 	}
-	r.Config = config
-	r.RegionScope = "fixed"
 	r.Policy = d.Get("policy").(string)
 	if v, ok := d.GetOk("notes"); ok {
 		r.Notes = v.(string)
@@ -248,9 +338,198 @@ func resourceDataToMonitoringJob(r *monitor.Job, d *schema.ResourceData) error {
 	if v, ok := d.GetOk("notify_list"); ok {
 		r.NotifyListID = v.(string)
 	}
+	return resourceDataToMonitoringJobMaintenance(r, d)
+}
+
+// checkMonitoringJob validates the job type, regions and rule keys of the
+// job against the NS1 monitoring catalogue, and converts the rule values to
+// the type of the metric they are compared against. The API compares values
+// as given, so sending numbers as strings results in lexical comparisons.
+func checkMonitoringJob(client *ns1.Client, j *monitor.Job) error {
+	catalogue := newMonitoringService(client)
+	jobTypes, _, err := catalogue.JobTypes()
+	if err != nil {
+		return err
+	}
+	jt, ok := jobTypes[j.Type]
+	if !ok {
+		return fmt.Errorf("unknown job_type %q, expecting one of %q", j.Type, jobTypeNames(jobTypes))
+	}
+
+	if len(j.Regions) > 0 {
+		regions, _, err := catalogue.Regions()
+		if err != nil {
+			return err
+		}
+		known := make([]string, len(regions))
+		for i, r := range regions {
+			known[i] = r.Code
+		}
+		for _, region := range j.Regions {
+			if !stringInSlice(region, known) {
+				return fmt.Errorf("unknown monitoring region %q, expecting one of %q", region, known)
+			}
+		}
+	}
+
+	for _, rule := range j.Rules {
+		result, ok := jt.Results[rule.Key]
+		if !ok {
+			return fmt.Errorf("rule key %q is not a result of job_type %q, expecting one of %q",
+				rule.Key, j.Type, jt.resultNames())
+		}
+		if len(result.Comparators) > 0 && !stringInSlice(rule.Comparison, result.Comparators) {
+			return fmt.Errorf("rule %s: comparison %q not supported, expecting one of %q",
+				rule.Key, rule.Comparison, result.Comparators)
+		}
+		v, err := monitoringJobRuleValue(result, rule.Value.(string))
+		if err != nil {
+			return fmt.Errorf("rule %s: %s", rule.Key, err)
+		}
+		rule.Value = v
+	}
 	return nil
 }
 
+// monitoringJobRuleValue converts a rule value to the type of the metric.
+func monitoringJobRuleValue(result *monitor.Result, value string) (interface{}, error) {
+	switch result.Type {
+	case "number":
+		if i, err := strconv.Atoi(value); err == nil {
+			return i, nil
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("expecting a number, got %q", value)
+		}
+		return f, nil
+	case "bool", "boolean":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("expecting a boolean, got %q", value)
+		}
+		return b, nil
+	}
+	return value, nil
+}
+
+// monitoringJobRuleValueString formats a rule value returned by the API the
+// way it is written in the configuration.
+func monitoringJobRuleValueString(v interface{}) string {
+	switch t := v.(type) {
+	case string:
+		return t
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(t)
+	}
+	return fmt.Sprint(v)
+}
+
+// monitoringJobRuleValueDiffSuppress treats rule values that only differ in
+// their formatting, e.g. 200 and 200.0, as equal.
+func monitoringJobRuleValueDiffSuppress(k, old, new string, d *schema.ResourceData) bool {
+	if of, err := strconv.ParseFloat(old, 64); err == nil {
+		if nf, err := strconv.ParseFloat(new, 64); err == nil {
+			return of == nf
+		}
+	}
+	if ob, err := strconv.ParseBool(old); err == nil {
+		if nb, err := strconv.ParseBool(new); err == nil {
+			return ob == nb
+		}
+	}
+	return false
+}
+
+func stringInSlice(s string, l []string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// monitoringJobStatusReached returns whether the regional statuses of the
+// job agree on the given status as required by the policy: in all regions,
+// in at least one, or in a majority of them. Statuses of regions the job no
+// longer runs in are ignored, as is the global status.
+func monitoringJobStatusReached(j *monitor.Job, status, policy string) bool {
+	regions := len(j.Regions)
+	matching := 0
+	for region, s := range j.Status {
+		if region == monitoringGlobalStatusKey || s == nil {
+			continue
+		}
+		if len(j.Regions) == 0 {
+			regions++
+		} else if !stringInSlice(region, j.Regions) {
+			continue
+		}
+		if s.Status == status {
+			matching++
+		}
+	}
+	if regions == 0 {
+		return false
+	}
+	switch policy {
+	case "all":
+		return matching == regions
+	case "one":
+		return matching > 0
+	default:
+		return 2*matching > regions
+	}
+}
+
+// waitForMonitoringJobStatus polls the job until it reaches the status
+// configured in wait_for_status, so that records fed by the job are not
+// updated before its first checks have completed.
+func waitForMonitoringJobStatus(client *ns1.Client, d *schema.ResourceData, timeout time.Duration) error {
+	v, ok := d.GetOk("wait_for_status")
+	if !ok {
+		return nil
+	}
+	paused, _, err := monitoringJobMaintenance(d, time.Now())
+	if err != nil {
+		return err
+	}
+	if !d.Get("active").(bool) || paused {
+		log.Printf("[WARN] Not waiting for status of inactive monitoring job %s", d.Id())
+		return nil
+	}
+	wait := v.([]interface{})[0].(map[string]interface{})
+	status := wait["status"].(string)
+	policy := wait["policy"].(string)
+	if policy == "" {
+		policy = d.Get("policy").(string)
+	}
+
+	var j *monitor.Job
+	err = resource.Retry(timeout, func() *resource.RetryError {
+		var err error
+		j, _, err = client.Jobs.Get(d.Id())
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if !monitoringJobStatusReached(j, status, policy) {
+			return resource.RetryableError(fmt.Errorf(
+				"monitoring job %s did not reach status %q in %s of its regions", d.Id(), status, policy,
+			))
+		}
+		return nil
+	})
+	if j != nil {
+		if err := monitoringJobStatusToResourceData(d, j); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
 // MonitoringJobCreate Creates monitoring job in ns1
 func MonitoringJobCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
@@ -258,10 +537,16 @@ func MonitoringJobCreate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToMonitoringJob(&j, d); err != nil {
 		return err
 	}
+	if err := checkMonitoringJob(client, &j); err != nil {
+		return err
+	}
 	if _, err := client.Jobs.Create(&j); err != nil {
 		return err
 	}
-	return monitoringJobToResourceData(d, &j)
+	if err := monitoringJobToResourceData(d, &j); err != nil {
+		return err
+	}
+	return waitForMonitoringJobStatus(client, d, d.Timeout(schema.TimeoutCreate))
 }
 
 // MonitoringJobRead reads the given monitoring job from ns1
@@ -291,8 +576,14 @@ func MonitoringJobUpdate(d *schema.ResourceData, meta interface{}) error {
 	if err := resourceDataToMonitoringJob(&j, d); err != nil {
 		return err
 	}
+	if err := checkMonitoringJob(client, &j); err != nil {
+		return err
+	}
 	if _, err := client.Jobs.Update(&j); err != nil {
 		return err
 	}
-	return monitoringJobToResourceData(d, &j)
+	if err := monitoringJobToResourceData(d, &j); err != nil {
+		return err
+	}
+	return waitForMonitoringJobStatus(client, d, d.Timeout(schema.TimeoutUpdate))
 }
@@ -3,6 +3,7 @@ package ns1
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -42,6 +43,75 @@ func TestAccMonitoringJob_basic(t *testing.T) {
 	})
 }
 
+func TestMonitoringJobStatusReached(t *testing.T) {
+	up := &monitor.Status{Status: "up"}
+	down := &monitor.Status{Status: "down"}
+	cases := map[string]struct {
+		Job      *monitor.Job
+		Policy   string
+		Expected bool
+	}{
+		"all_up": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up, "sjc": up}},
+			"all", true,
+		},
+		"all_one_down": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up, "sjc": down}},
+			"all", false,
+		},
+		"all_one_pending": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up}},
+			"all", false,
+		},
+		"one_up": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up, "sjc": down}},
+			"one", true,
+		},
+		"one_none_up": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": down, "sjc": down}},
+			"one", false,
+		},
+		"quorum_majority": {
+			&monitor.Job{Regions: []string{"lga", "sjc", "ams"}, Status: map[string]*monitor.Status{"lga": up, "sjc": up, "ams": down}},
+			"quorum", true,
+		},
+		"quorum_half": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up, "sjc": down}},
+			"quorum", false,
+		},
+		"global_only": {
+			&monitor.Job{Regions: []string{"lga"}, Status: map[string]*monitor.Status{"global": up}},
+			"one", false,
+		},
+		"global_ignored": {
+			&monitor.Job{Regions: []string{"lga"}, Status: map[string]*monitor.Status{"global": down, "lga": up}},
+			"all", true,
+		},
+		"unknown_region": {
+			&monitor.Job{Regions: []string{"lga", "sjc"}, Status: map[string]*monitor.Status{"lga": up, "ams": up}},
+			"all", false,
+		},
+		"unknown_region_only": {
+			&monitor.Job{Regions: []string{"lga"}, Status: map[string]*monitor.Status{"ams": up}},
+			"one", false,
+		},
+		"all_regions": {
+			&monitor.Job{Status: map[string]*monitor.Status{"lga": up, "sjc": up, "global": up}},
+			"all", true,
+		},
+		"no_status": {
+			&monitor.Job{Status: map[string]*monitor.Status{}},
+			"one", false,
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := monitoringJobStatusReached(tc.Job, "up", tc.Policy); got != tc.Expected {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, got)
+		}
+	}
+}
+
 func TestAccMonitoringJob_updated(t *testing.T) {
 	var mj monitor.Job
 	resource.Test(t, resource.TestCase{
@@ -82,15 +152,176 @@ func TestAccMonitoringJob_updated(t *testing.T) {
 					testAccCheckMonitoringJobConfigSend(&mj, "HEAD / HTTP/1.0\r\n\r\n"),
 					testAccCheckMonitoringJobConfigPort(&mj, 443),
 					testAccCheckMonitoringJobConfigHost(&mj, "1.1.1.1"),
-					testAccCheckMonitoringJobRuleValue(&mj, "200"),
+					testAccCheckMonitoringJobRuleValue(&mj, float64(200)),
 					testAccCheckMonitoringJobRuleComparison(&mj, "<="),
 					testAccCheckMonitoringJobRuleKey(&mj, "connect"),
+					testAccCheckMonitoringJobState("rules.0.value", "200"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_status(t *testing.T) {
+	var mj monitor.Job
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMonitoringJobBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+				),
+			},
+			resource.TestStep{
+				// The job has been running in its regions by the time it is
+				// refreshed.
+				Config: testAccMonitoringJobBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobState("status.#", "1"),
+					testAccCheckMonitoringJobState("status.0.region", "lga"),
+					resource.TestCheckResourceAttrSet("ns1_monitoringjob.it", "status.0.status"),
+					resource.TestCheckResourceAttrSet("ns1_monitoringjob.it", "global_status"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_httpConfig(t *testing.T) {
+	var mj monitor.Job
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMonitoringJobHTTPConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+					testAccCheckMonitoringJobType(&mj, "http"),
+					testAccCheckMonitoringJobConfigURL(&mj, "https://www.example.com/health"),
+					testAccCheckMonitoringJobConfigHeader(&mj, "Host", "www.example.com"),
+					testAccCheckMonitoringJobState("http_config.#", "1"),
+					testAccCheckMonitoringJobState("http_config.0.method", "HEAD"),
+					testAccCheckMonitoringJobState("http_config.0.headers.Host", "www.example.com"),
+					testAccCheckMonitoringJobState("http_config.0.follow_redirect", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_configMismatch(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccMonitoringJobConfigMismatch,
+				ExpectError: regexp.MustCompile(`tcp_config cannot be used with job_type "http"`),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_allRegions(t *testing.T) {
+	var mj monitor.Job
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMonitoringJobAllRegions,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+					testAccCheckMonitoringJobState("region_scope", "all"),
+					resource.TestCheckResourceAttrSet("ns1_monitoringjob.it", "regions.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_fixedWithoutRegions(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccMonitoringJobFixedWithoutRegions,
+				ExpectError: regexp.MustCompile(`regions must be set when region_scope is "fixed"`),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_waitForStatus(t *testing.T) {
+	var mj monitor.Job
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMonitoringJobWaitForStatus,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+					testAccCheckMonitoringJobState("status.0.status", "up"),
+					testAccCheckMonitoringJobState("status.1.status", "up"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMonitoringJob_maintenance(t *testing.T) {
+	var mj monitor.Job
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccMonitoringJobMaintenance,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+					testAccCheckMonitoringJobActive(&mj, false),
+					testAccCheckMonitoringJobState("active", "true"),
+					testAccCheckMonitoringJobState("in_maintenance", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccMonitoringJobMaintenanceEnded,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMonitoringJobExists("ns1_monitoringjob.it", &mj),
+					testAccCheckMonitoringJobActive(&mj, true),
+					testAccCheckMonitoringJobState("in_maintenance", "false"),
 				),
 			},
 		},
 	})
 }
 
+func TestAccMonitoringJob_unknownRegion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckMonitoringJobDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config:      testAccMonitoringJobUnknownRegion,
+				ExpectError: regexp.MustCompile(`unknown monitoring region "nowhere"`),
+			},
+		},
+	})
+}
+
 func testAccCheckMonitoringJobState(key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources["ns1_monitoringjob.it"]
@@ -251,10 +482,29 @@ func testAccCheckMonitoringJobConfigHost(mj *monitor.Job, expected string) resou
 	}
 }
 
-func testAccCheckMonitoringJobRuleValue(mj *monitor.Job, expected string) resource.TestCheckFunc {
+func testAccCheckMonitoringJobConfigURL(mj *monitor.Job, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if mj.Config["url"].(string) != expected {
+			return fmt.Errorf("Config.url: got: %#v want: %#v", mj.Config["url"], expected)
+		}
+		return nil
+	}
+}
+
+func testAccCheckMonitoringJobConfigHeader(mj *monitor.Job, header, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		headers, ok := mj.Config["headers"].(map[string]interface{})
+		if !ok || headers[header] != expected {
+			return fmt.Errorf("Config.headers.%s: got: %#v want: %#v", header, mj.Config["headers"], expected)
+		}
+		return nil
+	}
+}
+
+func testAccCheckMonitoringJobRuleValue(mj *monitor.Job, expected interface{}) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		if mj.Rules[0].Value.(string) != expected {
-			return fmt.Errorf("Rules[0].Value: got: %#v want: %#v", mj.Rules[0].Value.(string), expected)
+		if !reflect.DeepEqual(mj.Rules[0].Value, expected) {
+			return fmt.Errorf("Rules[0].Value: got: %#v want: %#v", mj.Rules[0].Value, expected)
 		}
 		return nil
 	}
@@ -324,3 +574,145 @@ resource "ns1_monitoringjob" "it" {
   }
 }
 `
+
+const testAccMonitoringJobHTTPConfig = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "http"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  http_config {
+    url             = "https://www.example.com/health"
+    method          = "HEAD"
+    follow_redirect = true
+
+    headers = {
+      Host = "www.example.com"
+    }
+  }
+}
+`
+
+const testAccMonitoringJobConfigMismatch = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "http"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+`
+
+const testAccMonitoringJobUnknownRegion = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["nowhere"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+`
+
+const testAccMonitoringJobAllRegions = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  region_scope = "all"
+  frequency    = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+`
+
+const testAccMonitoringJobFixedWithoutRegions = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+}
+`
+
+const testAccMonitoringJobWaitForStatus = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga", "sjc"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.1.1.1"
+    port = 443
+  }
+
+  wait_for_status {
+    policy = "all"
+  }
+}
+`
+
+const testAccMonitoringJobMaintenance = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+
+  maintenance_window {
+    start = "2018-01-01T00:00:00Z"
+    end   = "2118-01-01T00:00:00Z"
+    pause = true
+  }
+
+  mute_until = "2018-01-01T00:00:00Z"
+}
+`
+
+const testAccMonitoringJobMaintenanceEnded = `
+resource "ns1_monitoringjob" "it" {
+  job_type = "tcp"
+  name     = "terraform test"
+
+  regions   = ["lga"]
+  frequency = 60
+
+  tcp_config {
+    host = "1.2.3.4"
+    port = 443
+  }
+
+  maintenance_window {
+    start = "2018-01-01T00:00:00Z"
+    end   = "2018-01-02T00:00:00Z"
+    pause = true
+  }
+}
+`
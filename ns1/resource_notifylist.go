@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -22,16 +24,16 @@ func notifyListResource() *schema.Resource {
 				Type:     schema.TypeList,
 				Optional: true,
 				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
+					Schema: addNotifierConfigSchema(map[string]*schema.Schema{
 						"type": {
 							Type:     schema.TypeString,
 							Required: true,
 						},
 						"config": {
 							Type:     schema.TypeMap,
-							Required: true,
+							Optional: true,
 						},
-					},
+					}),
 				},
 			},
 		},
@@ -47,16 +49,18 @@ func notifyListToResourceData(d *schema.ResourceData, nl *monitor.NotifyList) er
 	d.Set("name", nl.Name)
 
 	if len(nl.Notifications) > 0 {
+		prior := d.Get("notifications").([]interface{})
 		notifications := make([]map[string]interface{}, len(nl.Notifications))
 		for i, n := range nl.Notifications {
-			ni := make(map[string]interface{})
-			ni["type"] = n.Type
-			if n.Config != nil {
-				ni["config"] = n.Config
+			var p map[string]interface{}
+			if i < len(prior) {
+				p, _ = prior[i].(map[string]interface{})
 			}
-			notifications[i] = ni
+			notifications[i] = notificationToNotifier(n, p)
+		}
+		if err := d.Set("notifications", notifications); err != nil {
+			return fmt.Errorf("[DEBUG] Error setting notifications for: %s, error: %#v", nl.Name, err)
 		}
-		d.Set("notifications", notifications)
 	}
 	return nil
 }
@@ -67,17 +71,11 @@ func resourceDataToNotifyList(nl *monitor.NotifyList, d *schema.ResourceData) er
 	if rawNotifications := d.Get("notifications").([]interface{}); len(rawNotifications) > 0 {
 		ns := make([]*monitor.Notification, len(rawNotifications))
 		for i, notificationRaw := range rawNotifications {
-			ni := notificationRaw.(map[string]interface{})
-			config := ni["config"].(map[string]interface{})
-
-			switch ni["type"].(string) {
-			case "webhook":
-				ns[i] = monitor.NewWebNotification(config["url"].(string))
-			case "email":
-				ns[i] = monitor.NewEmailNotification(config["email"].(string))
-			case "datafeed":
-				ns[i] = monitor.NewFeedNotification(config["sourceid"].(string))
+			n, err := notifierToNotification(notificationRaw.(map[string]interface{}))
+			if err != nil {
+				return fmt.Errorf("notifications.%d: %s", i, err)
 			}
+			ns[i] = n
 		}
 		nl.Notifications = ns
 	}
@@ -2,6 +2,7 @@ package ns1
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -54,6 +55,42 @@ func TestAccNotifyList_updated(t *testing.T) {
 	})
 }
 
+func TestAccNotifyList_typed(t *testing.T) {
+	var nl monitor.NotifyList
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotifyListTyped,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNotifyListExists("ns1_notifylist.test", &nl),
+					testAccCheckNotifyListNotification(&nl, 0, "email", "email", "test@example.com"),
+					testAccCheckNotifyListNotification(&nl, 1, "slack", "channel", "#alerts"),
+					testAccCheckNotifyListNotification(&nl, 2, "pagerduty", "service_key", "abcdef"),
+					testAccCheckNotifyListState("notifications.1.slack_config.0.username", "ns1"),
+					testAccCheckNotifyListState("notifications.2.pagerduty_config.0.service_key", "abcdef"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNotifyList_missingConfig(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNotifyListDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccNotifyListMissingConfig,
+				ExpectError: regexp.MustCompile(`notifications.0: hipchat notifier is missing required config "room"`),
+			},
+		},
+	})
+}
+
 func testAccCheckNotifyListState(key, value string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources["ns1_notifylist.test"]
@@ -133,6 +170,22 @@ func testAccCheckNotifyListName(nl *monitor.NotifyList, expected string) resourc
 	}
 }
 
+func testAccCheckNotifyListNotification(nl *monitor.NotifyList, i int, notifierType, key, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(nl.Notifications) <= i {
+			return fmt.Errorf("Notifications: got %d want at least %d", len(nl.Notifications), i+1)
+		}
+		n := nl.Notifications[i]
+		if n.Type != notifierType {
+			return fmt.Errorf("Notifications.%d.Type: got: %#v want: %#v", i, n.Type, notifierType)
+		}
+		if n.Config[key] != value {
+			return fmt.Errorf("Notifications.%d.Config[%s]: got: %#v want: %#v", i, key, n.Config[key], value)
+		}
+		return nil
+	}
+}
+
 const testAccNotifyListBasic = `
 resource "ns1_notifylist" "test" {
   name = "terraform test"
@@ -156,3 +209,45 @@ resource "ns1_notifylist" "test" {
   }
 }
 `
+
+const testAccNotifyListTyped = `
+resource "ns1_notifylist" "test" {
+  name = "terraform test"
+
+  notifications {
+    type = "email"
+    email_config {
+      email = "test@example.com"
+    }
+  }
+
+  notifications {
+    type = "slack"
+    slack_config {
+      url      = "https://hooks.slack.com/services/T0/B0/XYZ"
+      username = "ns1"
+      channel  = "#alerts"
+    }
+  }
+
+  notifications {
+    type = "pagerduty"
+    pagerduty_config {
+      service_key = "abcdef"
+    }
+  }
+}
+`
+
+const testAccNotifyListMissingConfig = `
+resource "ns1_notifylist" "test" {
+  name = "terraform test"
+
+  notifications {
+    type = "hipchat"
+    config = {
+      token = "abcdef"
+    }
+  }
+}
+`
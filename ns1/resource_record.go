@@ -3,7 +3,7 @@ package ns1
 import (
 	"errors"
 	"fmt"
-	"log"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -208,11 +208,9 @@ func recordToResourceData(d *schema.ResourceData, r *dns.Record) error {
 	}
 	if len(r.Answers) > 0 {
 		ans := make([]map[string]interface{}, 0)
-		log.Printf("Got back from ns1 answers: %+v", r.Answers)
 		for _, answer := range r.Answers {
 			ans = append(ans, answerToMap(*answer))
 		}
-		log.Printf("Setting answers %+v", ans)
 		err := d.Set("answers", ans)
 		if err != nil {
 			return fmt.Errorf("[DEBUG] Error setting answers for: %s, error: %#v", r.Domain, err)
@@ -223,10 +221,9 @@ func recordToResourceData(d *schema.ResourceData, r *dns.Record) error {
 		for regionName, region := range r.Regions {
 			newRegion := make(map[string]interface{})
 			newRegion["name"] = regionName
-			newRegion["meta"] = region.Meta.StringMap()
+			newRegion["meta"] = metaToMap(&region.Meta)
 			regions = append(regions, newRegion)
 		}
-		log.Printf("Setting regions %+v", regions)
 		err := d.Set("regions", regions)
 		if err != nil {
 			return fmt.Errorf("[DEBUG] Error setting regions for: %s, error: %#v", r.Domain, err)
@@ -242,20 +239,49 @@ func answerToMap(a dns.Answer) map[string]interface{} {
 		m["region"] = a.RegionName
 	}
 	if a.Meta != nil {
-		log.Println("got meta: ", a.Meta)
-		m["meta"] = a.Meta.StringMap()
-		log.Println(m["meta"])
+		m["meta"] = metaToMap(a.Meta)
 	}
 	return m
 }
 
+// metaFromMap is data.MetaFromMap, with support for feed pointers as the value
+// of up, such as the up_meta of ns1_health_check. data.MetaFromMap only
+// accepts "1" as up, and turns anything else into false.
+func metaFromMap(m map[string]interface{}) *data.Meta {
+	meta := data.MetaFromMap(m)
+	if v, ok := m["up"]; ok {
+		if feed, ok := data.ParseType(v.(string)).(data.FeedPtr); ok && feed.FeedID != "" {
+			meta.Up = feed
+		}
+	}
+	return meta
+}
+
+// metaToMap is Meta.StringMap, with support for feed pointers as read from
+// the API. These are decoded as maps holding the feed id, which StringMap
+// cannot format, and are formatted as data.FeedPtr instead.
+func metaToMap(m *data.Meta) map[string]interface{} {
+	if m == nil {
+		return map[string]interface{}{}
+	}
+	c := *m
+	v := reflect.ValueOf(&c).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if feed, ok := f.Interface().(map[string]interface{}); ok {
+			if id, ok := feed["feed"].(string); ok {
+				f.Set(reflect.ValueOf(data.FeedPtr{FeedID: id}))
+			}
+		}
+	}
+	return c.StringMap()
+}
+
 func resourceDataToRecord(r *dns.Record, d *schema.ResourceData) error {
 	r.ID = d.Id()
-	log.Printf("answers from template: %+v, %T\n", d.Get("answers"), d.Get("answers"))
 
 	if answers := d.Get("answers").([]interface{}); len(answers) > 0 {
 		al := make([]*dns.Answer, len(answers))
-		log.Println("number of answers found:", len(answers))
 		for i, answerRaw := range answers {
 			answer := answerRaw.(map[string]interface{})
 			var a *dns.Answer
@@ -271,9 +297,7 @@ func resourceDataToRecord(r *dns.Record, d *schema.ResourceData) error {
 			}
 
 			if v, ok := answer["meta"]; ok {
-				log.Println("answer meta", v)
-				a.Meta = data.MetaFromMap(v.(map[string]interface{}))
-				log.Println(a.Meta)
+				a.Meta = metaFromMap(v.(map[string]interface{}))
 				errs := a.Meta.Validate()
 				if len(errs) > 0 {
 					return errJoin(append([]error{errors.New("found error/s in answer metadata")}, errs...), ",")
@@ -294,9 +318,7 @@ func resourceDataToRecord(r *dns.Record, d *schema.ResourceData) error {
 	}
 
 	if v, ok := d.GetOk("meta"); ok {
-		log.Println("record meta", v)
-		r.Meta = data.MetaFromMap(v.(map[string]interface{}))
-		log.Println(r.Meta)
+		r.Meta = metaFromMap(v.(map[string]interface{}))
 		errs := r.Meta.Validate()
 		if len(errs) > 0 {
 			return errJoin(append([]error{errors.New("found error/s in record metadata")}, errs...), ",")
@@ -338,11 +360,8 @@ func resourceDataToRecord(r *dns.Record, d *schema.ResourceData) error {
 			}
 
 			if v, ok := region["meta"]; ok {
-				log.Println("region meta", v)
-				meta := data.MetaFromMap(v.(map[string]interface{}))
-				log.Println("region meta object", meta)
+				meta := metaFromMap(v.(map[string]interface{}))
 				ns1R.Meta = *meta
-				log.Println(ns1R.Meta)
 				errs := ns1R.Meta.Validate()
 				if len(errs) > 0 {
 					return errJoin(append([]error{errors.New("found error/s in region/group metadata")}, errs...), ",")
@@ -354,9 +373,29 @@ func resourceDataToRecord(r *dns.Record, d *schema.ResourceData) error {
 	return nil
 }
 
+// validateRecordZone checks that records may be added to the given zone.
+// Linked zones take their records from the link target and cannot hold
+// records of their own.
+func validateRecordZone(client *ns1.Client, zone string) error {
+	z, _, err := client.Zones.Get(zone)
+	if err != nil {
+		if err == ns1.ErrZoneMissing {
+			return fmt.Errorf("cannot create record in zone %q: zone does not exist", zone)
+		}
+		return err
+	}
+	if z.Link != nil && *z.Link != "" {
+		return fmt.Errorf("cannot create record in zone %q: it is linked to %q", zone, *z.Link)
+	}
+	return nil
+}
+
 // RecordCreate creates DNS record in ns1
 func RecordCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if err := validateRecordZone(client, d.Get("zone").(string)); err != nil {
+		return err
+	}
 	r := dns.NewRecord(d.Get("zone").(string), d.Get("domain").(string), d.Get("type").(string))
 	if err := resourceDataToRecord(r, d); err != nil {
 		return err
@@ -1,8 +1,10 @@
 package ns1
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"regexp"
 	"sort"
 	"testing"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/hashicorp/terraform/terraform"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/data"
 	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
 )
 
@@ -116,6 +119,77 @@ func TestAccRecord_SRV(t *testing.T) {
 	})
 }
 
+func TestAccRecord_linkedZone(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckRecordDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRecordLinkedZone,
+				ExpectError: regexp.MustCompile(`cannot create record in zone "terraform-record-linked-test.io": it is linked to "terraform-record-test.io"`),
+			},
+		},
+	})
+}
+
+func TestMetaFromMap(t *testing.T) {
+	cases := map[string]struct {
+		Up       string
+		Expected interface{}
+	}{
+		"up":      {"1", true},
+		"down":    {"0", false},
+		"feed":    {`{"feed":"abc123"}`, data.FeedPtr{FeedID: "abc123"}},
+		"no_feed": {`{}`, false},
+	}
+
+	for tn, tc := range cases {
+		meta := metaFromMap(map[string]interface{}{"up": tc.Up})
+		if !reflect.DeepEqual(meta.Up, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, meta.Up)
+		}
+		if errs := meta.Validate(); len(errs) > 0 {
+			t.Fatalf("bad: %s: %v", tn, errs)
+		}
+	}
+}
+
+func TestMetaToMap(t *testing.T) {
+	cases := map[string]struct {
+		Meta     map[string]interface{}
+		Expected map[string]interface{}
+	}{
+		"up": {
+			map[string]interface{}{"up": "1"},
+			map[string]interface{}{"up": "1"},
+		},
+		"feed": {
+			map[string]interface{}{"up": `{"feed":"abc123"}`},
+			map[string]interface{}{"up": `{"feed":"abc123"}`},
+		},
+		"feed_and_values": {
+			map[string]interface{}{"up": `{"feed":"abc123"}`, "weight": "10", "country": "DE,US"},
+			map[string]interface{}{"up": `{"feed":"abc123"}`, "weight": "10", "country": "DE,US"},
+		},
+	}
+
+	for tn, tc := range cases {
+		// Round trip the meta through JSON, as sent to and read from the API.
+		b, err := json.Marshal(metaFromMap(tc.Meta))
+		if err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+		var meta data.Meta
+		if err := json.Unmarshal(b, &meta); err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+		if m := metaToMap(&meta); !reflect.DeepEqual(m, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, m)
+		}
+	}
+}
+
 func testAccCheckRecordExists(n string, record *dns.Record) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -376,3 +450,23 @@ resource "ns1_zone" "test" {
   zone = "terraform-record-test.io"
 }
 `
+
+const testAccRecordLinkedZone = `
+resource "ns1_record" "it" {
+  zone   = "${ns1_zone.linked.zone}"
+  domain = "test.${ns1_zone.linked.zone}"
+  type   = "CNAME"
+  answers {
+    answer = "test1.${ns1_zone.test.zone}"
+  }
+}
+
+resource "ns1_zone" "linked" {
+  zone = "terraform-record-linked-test.io"
+  link = "${ns1_zone.test.zone}"
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-record-test.io"
+}
+`
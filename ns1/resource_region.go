@@ -58,7 +58,7 @@ func regionToMap(regions data.Regions) map[string]interface{} {
 	name, region := getRegion(regions)
 	m["name"] = name
 	if region != nil {
-		m["meta"] = region.Meta.StringMap()
+		m["meta"] = metaToMap(&region.Meta)
 	}
 	return m
 }
@@ -87,7 +87,7 @@ func resourceDataToRegions(regions data.Regions, resourceData *schema.ResourceDa
 	}
 	var region data.Region
 	if meta != nil {
-		region.Meta = *data.MetaFromMap(meta)
+		region.Meta = *metaFromMap(meta)
 		errs := region.Meta.Validate()
 		if len(errs) > 0 {
 			return errJoin(append([]error{errors.New("found error/s in region metadata")}, errs...), ",")
@@ -163,7 +163,7 @@ func updateRecordForRegion(op string, meta interface{}, resourceData *schema.Res
 		region := data.Region{
 			Meta: data.Meta{},
 		}
-		meta := data.MetaFromMap(resourceData.Get("meta").(map[string]interface{}))
+		meta := metaFromMap(resourceData.Get("meta").(map[string]interface{}))
 		if meta == nil {
 			return nil, errors.New("could not read metadata")
 		}
@@ -184,7 +184,7 @@ func updateRecordForRegion(op string, meta interface{}, resourceData *schema.Res
 		}
 		// Replace the region
 		region := record.Regions[resourceData.Get("name").(string)]
-		meta := data.MetaFromMap(resourceData.Get("meta").(map[string]interface{}))
+		meta := metaFromMap(resourceData.Get("meta").(map[string]interface{}))
 		if meta == nil {
 			return nil, errors.New("could not read metadata")
 		}
@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -19,37 +21,46 @@ func teamResource() *schema.Resource {
 		},
 	}
 	s = addPermsSchema(s)
+	s = addIPWhitelistSchema(s)
 	return &schema.Resource{
-		Schema: s,
-		Create: TeamCreate,
-		Read:   TeamRead,
-		Update: TeamUpdate,
-		Delete: TeamDelete,
+		Schema:        s,
+		SchemaVersion: 2,
+		MigrateState:  migratePermissionsState,
+		Create:        TeamCreate,
+		Read:          TeamRead,
+		Update:        TeamUpdate,
+		Delete:        TeamDelete,
 	}
 }
 
-func teamToResourceData(d *schema.ResourceData, t *account.Team) error {
+func teamToResourceData(d *schema.ResourceData, t *accountTeam) error {
 	d.SetId(t.ID)
 	d.Set("name", t.Name)
-	permissionsToResourceData(d, t.Permissions)
+	if err := ipWhitelistToResourceData(d, t.ipWhitelist); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting ip_whitelist for: %s, error: %#v", t.ID, err)
+	}
+	if err := permissionsToResourceData(d, t.Permissions, permissionsMap{}); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting permissions for: %s, error: %#v", t.ID, err)
+	}
 	return nil
 }
 
-func resourceDataToTeam(t *account.Team, d *schema.ResourceData) error {
+func resourceDataToTeam(t *accountTeam, d *schema.ResourceData) error {
 	t.ID = d.Id()
 	t.Name = d.Get("name").(string)
 	t.Permissions = resourceDataToPermissions(d)
+	resourceDataToIPWhitelist(&t.ipWhitelist, d)
 	return nil
 }
 
 // TeamCreate creates the given team in ns1
 func TeamCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	t := account.Team{}
+	t := accountTeam{}
 	if err := resourceDataToTeam(&t, d); err != nil {
 		return err
 	}
-	if _, err := client.Teams.Create(&t); err != nil {
+	if _, err := newAccountService(client).CreateTeam(&t); err != nil {
 		return err
 	}
 	return teamToResourceData(d, &t)
@@ -58,7 +69,7 @@ func TeamCreate(d *schema.ResourceData, meta interface{}) error {
 // TeamRead reads the team data from ns1
 func TeamRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	t, _, err := client.Teams.Get(d.Id())
+	t, _, err := newAccountService(client).GetTeam(d.Id())
 	if err != nil {
 		return err
 	}
@@ -76,13 +87,15 @@ func TeamDelete(d *schema.ResourceData, meta interface{}) error {
 // TeamUpdate updates the given team in ns1
 func TeamUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	t := account.Team{
-		ID: d.Id(),
+	t := accountTeam{
+		Team: account.Team{
+			ID: d.Id(),
+		},
 	}
 	if err := resourceDataToTeam(&t, d); err != nil {
 		return err
 	}
-	if _, err := client.Teams.Update(&t); err != nil {
+	if _, err := newAccountService(client).UpdateTeam(&t); err != nil {
 		return err
 	}
 	return teamToResourceData(d, &t)
@@ -0,0 +1,256 @@
+package ns1
+
+import (
+	"github.com/hashicorp/terraform/helper/mutexkv"
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+)
+
+// Team membership is an attribute of users and API keys, so memberships are
+// changed by updating the teams of the user or key. membershipMutex
+// serializes these read modify writes within the provider.
+var membershipMutex = mutexkv.NewMutexKV()
+
+func teamMembershipResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"team_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"users": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"apikeys": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+			"authoritative": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+		Create:   TeamMembershipCreate,
+		Read:     TeamMembershipRead,
+		Update:   TeamMembershipUpdate,
+		Delete:   TeamMembershipDelete,
+		Importer: &schema.ResourceImporter{State: TeamMembershipStateFunc},
+	}
+}
+
+// setUserTeam adds the user to or removes the user from the team.
+func setUserTeam(client *ns1.Client, username, team string, member bool) error {
+	membershipMutex.Lock("user/" + username)
+	defer membershipMutex.Unlock("user/" + username)
+
+	u, _, err := newAccountService(client).GetUser(username)
+	if err != nil {
+		return err
+	}
+	teams, changed := setTeam(u.TeamIDs, team, member)
+	if !changed {
+		return nil
+	}
+	_, err = newAccountService(client).UpdateUserTeams(username, teams)
+	return err
+}
+
+// setAPIKeyTeam adds the API key to or removes the API key from the team.
+func setAPIKeyTeam(client *ns1.Client, id, team string, member bool) error {
+	membershipMutex.Lock("apikey/" + id)
+	defer membershipMutex.Unlock("apikey/" + id)
+
+	k, _, err := newAccountService(client).GetAPIKey(id)
+	if err != nil {
+		return err
+	}
+	teams, changed := setTeam(k.TeamIDs, team, member)
+	if !changed {
+		return nil
+	}
+	_, err = newAccountService(client).UpdateAPIKeyTeams(id, teams)
+	return err
+}
+
+// setTeam adds the team to or removes the team from the given teams, and
+// reports whether they changed.
+func setTeam(teams []string, team string, member bool) ([]string, bool) {
+	if stringInSlice(team, teams) == member {
+		return teams, false
+	}
+	if member {
+		return append(teams, team), true
+	}
+	l := make([]string, 0, len(teams))
+	for _, t := range teams {
+		if t != team {
+			l = append(l, t)
+		}
+	}
+	return l, true
+}
+
+// teamMembers returns the usernames of the users and the ids of the API keys
+// that are members of the team.
+func teamMembers(client *ns1.Client, team string) (users []string, apikeys []string, err error) {
+	ul, _, err := client.Users.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, u := range ul {
+		if stringInSlice(team, u.TeamIDs) {
+			users = append(users, u.Username)
+		}
+	}
+	kl, _, err := client.APIKeys.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, k := range kl {
+		if stringInSlice(team, k.TeamIDs) {
+			apikeys = append(apikeys, k.ID)
+		}
+	}
+	return users, apikeys, nil
+}
+
+// reconcileTeamMembership adds the declared users and API keys to the team
+// and removes the ones no longer declared. If authoritative is set, members
+// that were never declared are removed as well.
+func reconcileTeamMembership(client *ns1.Client, d *schema.ResourceData) error {
+	team := d.Get("team_id").(string)
+
+	oldUsers, newUsers := d.GetChange("users")
+	for _, username := range oldUsers.(*schema.Set).Difference(newUsers.(*schema.Set)).List() {
+		if err := setUserTeam(client, username.(string), team, false); err != nil {
+			return err
+		}
+	}
+	for _, username := range newUsers.(*schema.Set).List() {
+		if err := setUserTeam(client, username.(string), team, true); err != nil {
+			return err
+		}
+	}
+
+	oldKeys, newKeys := d.GetChange("apikeys")
+	for _, id := range oldKeys.(*schema.Set).Difference(newKeys.(*schema.Set)).List() {
+		if err := setAPIKeyTeam(client, id.(string), team, false); err != nil {
+			return err
+		}
+	}
+	for _, id := range newKeys.(*schema.Set).List() {
+		if err := setAPIKeyTeam(client, id.(string), team, true); err != nil {
+			return err
+		}
+	}
+
+	if !d.Get("authoritative").(bool) {
+		return nil
+	}
+	users, apikeys, err := teamMembers(client, team)
+	if err != nil {
+		return err
+	}
+	for _, username := range users {
+		if !newUsers.(*schema.Set).Contains(username) {
+			if err := setUserTeam(client, username, team, false); err != nil {
+				return err
+			}
+		}
+	}
+	for _, id := range apikeys {
+		if !newKeys.(*schema.Set).Contains(id) {
+			if err := setAPIKeyTeam(client, id, team, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// TeamMembershipCreate adds users and API keys to a team in ns1
+func TeamMembershipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	team := d.Get("team_id").(string)
+	if _, _, err := client.Teams.Get(team); err != nil {
+		return err
+	}
+	if err := reconcileTeamMembership(client, d); err != nil {
+		return err
+	}
+	d.SetId(team)
+	return TeamMembershipRead(d, meta)
+}
+
+// TeamMembershipRead reads the members of a team from ns1. Unless the
+// membership is authoritative, only the declared members are reported.
+func TeamMembershipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	users, apikeys, err := teamMembers(client, d.Id())
+	if err != nil {
+		return err
+	}
+	if !d.Get("authoritative").(bool) {
+		declaredUsers := d.Get("users").(*schema.Set)
+		declaredKeys := d.Get("apikeys").(*schema.Set)
+		var u, k []string
+		for _, username := range users {
+			if declaredUsers.Contains(username) {
+				u = append(u, username)
+			}
+		}
+		for _, id := range apikeys {
+			if declaredKeys.Contains(id) {
+				k = append(k, id)
+			}
+		}
+		users, apikeys = u, k
+	}
+	d.Set("team_id", d.Id())
+	d.Set("users", users)
+	d.Set("apikeys", apikeys)
+	return nil
+}
+
+// TeamMembershipUpdate updates the members of a team in ns1
+func TeamMembershipUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := reconcileTeamMembership(client, d); err != nil {
+		return err
+	}
+	return TeamMembershipRead(d, meta)
+}
+
+// TeamMembershipDelete removes the declared users and API keys from a team
+// in ns1
+func TeamMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	team := d.Id()
+	for _, username := range d.Get("users").(*schema.Set).List() {
+		if err := setUserTeam(client, username.(string), team, false); err != nil {
+			return err
+		}
+	}
+	for _, id := range d.Get("apikeys").(*schema.Set).List() {
+		if err := setAPIKeyTeam(client, id.(string), team, false); err != nil {
+			return err
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// TeamMembershipStateFunc imports all members of a team, authoritatively
+func TeamMembershipStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("team_id", d.Id())
+	d.Set("authoritative", true)
+	return []*schema.ResourceData{d}, nil
+}
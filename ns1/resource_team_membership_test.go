@@ -0,0 +1,189 @@
+package ns1
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/account"
+)
+
+func TestAccTeamMembership_basic(t *testing.T) {
+	var user account.User
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembershipBasic(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					testAccCheckUserTeams(&user, "ns1_team.t"),
+					testAccCheckTeamMembershipKey("ns1_apikey.k", "ns1_team.t"),
+					resource.TestCheckResourceAttr("ns1_team_membership.m", "users.#", "1"),
+					resource.TestCheckResourceAttr("ns1_team_membership.m", "apikeys.#", "1"),
+				),
+			},
+			{
+				Config: testAccTeamMembershipRemoved(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					testAccCheckUserTeams(&user),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeamMembership_permissions(t *testing.T) {
+	var before, user account.User
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamMembershipPermissions(rString, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &before),
+				),
+			},
+			{
+				Config: testAccTeamMembershipPermissions(rString, `
+resource "ns1_team_membership" "m" {
+  team_id = "${ns1_team.t.id}"
+  users   = ["${ns1_user.u.id}"]
+}
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					testAccCheckUserTeams(&user, "ns1_team.t"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.dns.0.view_zones", "true"),
+				),
+			},
+			{
+				Config: testAccTeamMembershipPermissions(rString, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					testAccCheckUserTeams(&user),
+					testAccCheckUserPermissions(&user, &before),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckUserTeams checks that the user is a member of exactly the
+// given teams.
+func testAccCheckUserTeams(user *account.User, teams ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if len(user.TeamIDs) != len(teams) {
+			return fmt.Errorf("Teams: got: %#v want %d teams", user.TeamIDs, len(teams))
+		}
+		for _, n := range teams {
+			rs, ok := s.RootModule().Resources[n]
+			if !ok {
+				return fmt.Errorf("Not found: %s", n)
+			}
+			if !stringInSlice(rs.Primary.ID, user.TeamIDs) {
+				return fmt.Errorf("Teams: got: %#v want: %s", user.TeamIDs, rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckUserPermissions checks that the user has the same permissions
+// as the other user.
+func testAccCheckUserPermissions(user, other *account.User) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if !reflect.DeepEqual(user.Permissions, other.Permissions) {
+			return fmt.Errorf("Permissions: got: %#v want: %#v", user.Permissions, other.Permissions)
+		}
+		return nil
+	}
+}
+
+func testAccCheckTeamMembershipKey(n, team string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+		client := testAccProvider.Meta().(*ns1.Client)
+		k, _, err := client.APIKeys.Get(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if !stringInSlice(s.RootModule().Resources[team].Primary.ID, k.TeamIDs) {
+			return fmt.Errorf("Teams: got: %#v want: %s", k.TeamIDs, team)
+		}
+		return nil
+	}
+}
+
+func testAccTeamMembershipConfig(rString, membership string) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+}
+
+resource "ns1_user" "u" {
+  name = "terraform acc test user %s"
+  username = "tf_acc_test_user_%s"
+  email = "tf_acc_test_ns1@hashicorp.com"
+}
+
+resource "ns1_apikey" "k" {
+  name = "terraform acc test key %s"
+}
+%s
+`, rString, rString, rString, rString, membership)
+}
+
+func testAccTeamMembershipBasic(rString string) string {
+	return testAccTeamMembershipConfig(rString, `
+resource "ns1_team_membership" "m" {
+  team_id = "${ns1_team.t.id}"
+  users   = ["${ns1_user.u.id}"]
+  apikeys = ["${ns1_apikey.k.id}"]
+}
+`)
+}
+
+func testAccTeamMembershipRemoved(rString string) string {
+	return testAccTeamMembershipConfig(rString, "")
+}
+
+func testAccTeamMembershipPermissions(rString, membership string) string {
+	return fmt.Sprintf(`resource "ns1_team" "t" {
+  name = "terraform acc test team %s"
+  permissions {
+    dns {
+      view_zones = true
+    }
+  }
+}
+
+resource "ns1_user" "u" {
+  name = "terraform acc test user %s"
+  username = "tf_acc_test_user_%s"
+  email = "tf_acc_test_ns1@hashicorp.com"
+  permissions {
+    data {
+      manage_datafeeds = true
+    }
+  }
+}
+%s
+`, rString, rString, rString, membership)
+}
@@ -67,6 +67,123 @@ func TestAccTeam_updated(t *testing.T) {
 	})
 }
 
+func TestAccTeam_role(t *testing.T) {
+	var team account.Team
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamRole,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "role", "read_only"),
+					// Granted by the role
+					testAccCheckTeamDNSPermission(&team, "view_zones", true),
+					// Granted explicitly
+					testAccCheckTeamDataPermission(&team, "manage_datasources", true),
+					testAccCheckTeamDNSPermission(&team, "manage_zones", false),
+				),
+			},
+			{
+				Config: testAccTeamRoleUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					testAccCheckTeamDNSPermission(&team, "view_zones", true),
+					testAccCheckTeamDNSPermission(&team, "manage_zones", true),
+					testAccCheckTeamDataPermission(&team, "manage_datasources", true),
+					testAccCheckTeamDataPermission(&team, "manage_datafeeds", true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeam_roleOverride(t *testing.T) {
+	var team account.Team
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamRoleOverride,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.0.account.0.manage_users", "false"),
+					// Revoked by the override, the rest of the role is kept.
+					testAccCheckTeamExtendedPermissions("ns1_team.foobar", func(p permissionsMap) bool {
+						return !p.Account.ManageUsers && p.Account.ManagePlan && p.DNS.ManageZones
+					}),
+				),
+			},
+			{
+				Config: testAccTeamRoleOverrideRemoved,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.#", "0"),
+					testAccCheckTeamExtendedPermissions("ns1_team.foobar", func(p permissionsMap) bool {
+						return p.Account.ManageUsers && p.Account.ManagePlan
+					}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeam_permissionFamilies(t *testing.T) {
+	var team account.Team
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamPermissionFamilies,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					testAccCheckTeamExtendedPermissions("ns1_team.foobar", func(p permissionsMap) bool {
+						return p.Account.ManageIPWhitelist &&
+							p.Security.ManageGlobal2FA &&
+							p.DHCP.ViewDHCP &&
+							p.IPAM.ManageIPAM &&
+							len(p.DNS.RecordsAllow) == 1 &&
+							p.DNS.RecordsAllow[0].Domain == "www.mytest.zone"
+					}),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.0.dns.0.records_allow.0.zone", "mytest.zone"),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.0.dns.0.records_allow.0.subdomains", "true"),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.0.security.0.manage_global_2fa", "true"),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "permissions.0.ipam.0.manage_ipam", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeam_ipWhitelist(t *testing.T) {
+	var team account.Team
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckTeamDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccTeamIPWhitelist,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckTeamExists("ns1_team.foobar", &team),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "ip_whitelist.#", "2"),
+					resource.TestCheckResourceAttr("ns1_team.foobar", "ip_whitelist_strict", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckTeamExists(n string, team *account.Team) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -167,6 +284,30 @@ func testAccCheckTeamDataPermission(team *account.Team, perm string, expected bo
 	}
 }
 
+// testAccCheckTeamExtendedPermissions checks the permissions of the team,
+// including those missing from the vendored client.
+func testAccCheckTeamExtendedPermissions(n string, check func(permissionsMap) bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		t, _, err := newAccountService(client).GetTeam(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if !check(t.Permissions) {
+			return fmt.Errorf("Permissions: got: %#v", t.Permissions)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckTeamDNSPermissionZones(team *account.Team, perm string, expected []string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		dns := team.Permissions.DNS
@@ -190,20 +331,116 @@ const testAccTeamBasic = `
 resource "ns1_team" "foobar" {
   name = "terraform test"
 
-  dns_view_zones = true
-  dns_zones_allow_by_default = true
-  dns_zones_allow = ["mytest.zone"]
-  dns_zones_deny = ["myother.zone"]
-
-  data_manage_datasources = true
+  permissions {
+    dns {
+      view_zones = true
+      zones_allow_by_default = true
+      zones_allow = ["mytest.zone"]
+      zones_deny = ["myother.zone"]
+    }
+
+    data {
+      manage_datasources = true
+    }
+  }
 }`
 
 const testAccTeamUpdated = `
 resource "ns1_team" "foobar" {
   name = "terraform test updated"
 
-  dns_view_zones = true
-  dns_zones_allow_by_default = true
+  permissions {
+    dns {
+      view_zones = true
+      zones_allow_by_default = true
+    }
+
+    data {
+      manage_datasources = false
+    }
+  }
+}`
+
+const testAccTeamRole = `
+resource "ns1_team" "foobar" {
+  name = "terraform test role"
+  role = "read_only"
+
+  permissions {
+    data {
+      manage_datasources = true
+    }
+  }
+}`
+
+const testAccTeamRoleUpdated = `
+resource "ns1_team" "foobar" {
+  name = "terraform test role"
+  role = "dns_admin"
+
+  permissions {
+    data {
+      manage_datasources = true
+    }
+  }
+}`
+
+const testAccTeamRoleOverride = `
+resource "ns1_team" "foobar" {
+  name = "terraform test role override"
+  role = "full_admin"
+
+  permissions {
+    account {
+      manage_users = false
+    }
+  }
+}`
+
+const testAccTeamRoleOverrideRemoved = `
+resource "ns1_team" "foobar" {
+  name = "terraform test role override"
+  role = "full_admin"
+}`
+
+const testAccTeamPermissionFamilies = `
+resource "ns1_team" "foobar" {
+  name = "terraform test permission families"
+
+  permissions {
+    dns {
+      view_zones = true
+
+      records_allow {
+        domain     = "www.mytest.zone"
+        subdomains = true
+        zone       = "mytest.zone"
+        type       = "A"
+      }
+    }
+
+    account {
+      manage_ip_whitelist = true
+    }
+
+    security {
+      manage_global_2fa = true
+    }
+
+    dhcp {
+      view_dhcp = true
+    }
+
+    ipam {
+      manage_ipam = true
+    }
+  }
+}`
+
+const testAccTeamIPWhitelist = `
+resource "ns1_team" "foobar" {
+  name = "terraform test ip whitelist"
 
-  data_manage_datasources = false
+  ip_whitelist        = ["10.0.0.0/8", "192.0.2.1"]
+  ip_whitelist_strict = true
 }`
@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/terraform/helper/schema"
 
 	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
@@ -33,20 +35,25 @@ func userResource() *schema.Resource {
 		"teams": {
 			Type:     schema.TypeList,
 			Optional: true,
+			Computed: true,
 			Elem:     &schema.Schema{Type: schema.TypeString},
 		},
 	}
 	s = addPermsSchema(s)
+	s = addIPWhitelistSchema(s)
+	s["effective_permissions"] = effectivePermissionsSchema()
 	return &schema.Resource{
-		Schema: s,
-		Create: UserCreate,
-		Read:   UserRead,
-		Update: UserUpdate,
-		Delete: UserDelete,
+		Schema:        s,
+		SchemaVersion: 2,
+		MigrateState:  migratePermissionsState,
+		Create:        UserCreate,
+		Read:          UserRead,
+		Update:        UserUpdate,
+		Delete:        UserDelete,
 	}
 }
 
-func userToResourceData(d *schema.ResourceData, u *account.User) error {
+func userToResourceData(d *schema.ResourceData, u *accountUser, inherited permissionsMap) error {
 	d.SetId(u.Username)
 	d.Set("name", u.Name)
 	d.Set("email", u.Email)
@@ -54,11 +61,16 @@ func userToResourceData(d *schema.ResourceData, u *account.User) error {
 	notify := make(map[string]bool)
 	notify["billing"] = u.Notify.Billing
 	d.Set("notify", notify)
-	permissionsToResourceData(d, u.Permissions)
-	return nil
+	if err := ipWhitelistToResourceData(d, u.ipWhitelist); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting ip_whitelist for: %s, error: %#v", u.Username, err)
+	}
+	if err := permissionsToResourceData(d, u.Permissions, inherited); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting permissions for: %s, error: %#v", u.Username, err)
+	}
+	return effectivePermissionsToResourceData(d, u.Permissions)
 }
 
-func resourceDataToUser(u *account.User, d *schema.ResourceData) error {
+func resourceDataToUser(u *accountUser, d *schema.ResourceData) error {
 	u.Name = d.Get("name").(string)
 	u.Username = d.Get("username").(string)
 	u.Email = d.Get("email").(string)
@@ -76,30 +88,39 @@ func resourceDataToUser(u *account.User, d *schema.ResourceData) error {
 		u.Notify.Billing = notifyRaw["billing"].(bool)
 	}
 	u.Permissions = resourceDataToPermissions(d)
+	resourceDataToIPWhitelist(&u.ipWhitelist, d)
 	return nil
 }
 
 // UserCreate creates the given user in ns1
 func UserCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	u := account.User{}
+	u := accountUser{}
 	if err := resourceDataToUser(&u, d); err != nil {
 		return err
 	}
-	if _, err := client.Users.Create(&u); err != nil {
+	if _, err := newAccountService(client).CreateUser(&u); err != nil {
+		return err
+	}
+	inherited, err := teamsPermissions(client, u.TeamIDs)
+	if err != nil {
 		return err
 	}
-	return userToResourceData(d, &u)
+	return userToResourceData(d, &u, inherited)
 }
 
 // UserRead  reads the given users data from ns1
 func UserRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	u, _, err := client.Users.Get(d.Id())
+	u, _, err := newAccountService(client).GetUser(d.Id())
 	if err != nil {
 		return err
 	}
-	return userToResourceData(d, u)
+	inherited, err := teamsPermissions(client, u.TeamIDs)
+	if err != nil {
+		return err
+	}
+	return userToResourceData(d, u, inherited)
 }
 
 // UserDelete deletes the given user from ns1
@@ -113,14 +134,20 @@ func UserDelete(d *schema.ResourceData, meta interface{}) error {
 // UserUpdate updates the user with given parameters in ns1
 func UserUpdate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	u := account.User{
-		Username: d.Id(),
+	u := accountUser{
+		User: account.User{
+			Username: d.Id(),
+		},
 	}
 	if err := resourceDataToUser(&u, d); err != nil {
 		return err
 	}
-	if _, err := client.Users.Update(&u); err != nil {
+	if _, err := newAccountService(client).UpdateUser(&u); err != nil {
+		return err
+	}
+	inherited, err := teamsPermissions(client, u.TeamIDs)
+	if err != nil {
 		return err
 	}
-	return userToResourceData(d, &u)
+	return userToResourceData(d, &u, inherited)
 }
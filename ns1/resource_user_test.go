@@ -39,6 +39,55 @@ func TestAccUser_basic(t *testing.T) {
 	})
 }
 
+func TestAccUser_inheritedPermissions(t *testing.T) {
+	var user account.User
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserInheritedPermissions(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					resource.TestCheckResourceAttr("ns1_user.u", "teams.#", "2"),
+					// Granted by the teams only
+					resource.TestCheckResourceAttr("ns1_user.u", "permissions.0.dns.#", "0"),
+					resource.TestCheckResourceAttr("ns1_user.u", "permissions.0.monitoring.#", "0"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.dns.0.view_zones", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.monitoring.0.view_jobs", "true"),
+					// Set explicitly
+					resource.TestCheckResourceAttr("ns1_user.u", "permissions.0.data.0.manage_datafeeds", "true"),
+					resource.TestCheckResourceAttr("ns1_user.u", "effective_permissions.0.data.0.manage_datafeeds", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccUser_ipWhitelist(t *testing.T) {
+	var user account.User
+	rString := acctest.RandStringFromCharSet(15, acctest.CharSetAlphaNum)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserIPWhitelist(rString),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckUserExists("ns1_user.u", &user),
+					resource.TestCheckResourceAttr("ns1_user.u", "ip_whitelist.#", "1"),
+					resource.TestCheckResourceAttr("ns1_user.u", "ip_whitelist_strict", "false"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckUserDestroy(s *terraform.State) error {
 	client := testAccProvider.Meta().(*ns1.Client)
 
@@ -100,3 +149,52 @@ resource "ns1_user" "u" {
 }
 `, rString, rString, rString)
 }
+
+func testAccUserInheritedPermissions(rString string) string {
+	return fmt.Sprintf(`resource "ns1_team" "dns" {
+  name = "terraform acc test dns team %s"
+  permissions {
+    dns {
+      view_zones = true
+    }
+  }
+}
+
+resource "ns1_team" "monitoring" {
+  name = "terraform acc test monitoring team %s"
+  permissions {
+    monitoring {
+      view_jobs = true
+    }
+  }
+}
+
+resource "ns1_user" "u" {
+  name = "terraform acc test user %s"
+  username = "tf_acc_test_user_%s"
+  email = "tf_acc_test_ns1@hashicorp.com"
+  teams = ["${ns1_team.dns.id}", "${ns1_team.monitoring.id}"]
+  notify {
+  	billing = false
+  }
+  permissions {
+    data {
+      manage_datafeeds = true
+    }
+  }
+}
+`, rString, rString, rString, rString)
+}
+
+func testAccUserIPWhitelist(rString string) string {
+	return fmt.Sprintf(`resource "ns1_user" "u" {
+  name = "terraform acc test user %s"
+  username = "tf_acc_test_user_%s"
+  email = "tf_acc_test_ns1@hashicorp.com"
+  notify {
+  	billing = false
+  }
+  ip_whitelist = ["2001:db8::/32"]
+}
+`, rString, rString)
+}
@@ -1,6 +1,8 @@
 package ns1
 
 import (
+	"fmt"
+	"log"
 	"strings"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -46,11 +48,12 @@ func zoneResource() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
-			// TODO: test
 			"link": {
 				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
+				// Linked zones take all of their configuration from the target.
+				ConflictsWith: []string{"ttl", "refresh", "retry", "expiry", "nx_ttl", "primary", "networks"},
 			},
 			// TODO: test
 			"primary": {
@@ -58,6 +61,25 @@ func zoneResource() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"dnssec": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"link", "primary"},
+			},
+			"force_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			// Finding the links takes a listing of all zones in the account,
+			// and a request for each of them, on every refresh, so it is
+			// opt-in.
+			"list_linked_zones": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
 			// Computed
 			"id": {
 				Type:     schema.TypeString,
@@ -67,10 +89,34 @@ func zoneResource() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"dns_servers_list": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"network_pools": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"serial": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
 			"hostmaster": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"linked_zones": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"linked_records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
 			"networks": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -95,6 +141,9 @@ func zoneToResourceData(d *schema.ResourceData, z *dns.Zone) {
 	d.Set("expiry", z.Expiry)
 	d.Set("networks", z.NetworkIDs)
 	d.Set("dns_servers", strings.Join(z.DNSServers[:], ","))
+	d.Set("dns_servers_list", z.DNSServers)
+	d.Set("network_pools", z.NetworkPools)
+	d.Set("serial", z.Serial)
 	if z.Secondary != nil && z.Secondary.Enabled {
 		d.Set("primary", z.Secondary.PrimaryIP)
 	}
@@ -139,33 +188,169 @@ func resourceToZoneData(z *dns.Zone, d *schema.ResourceData) {
 	}
 }
 
+// validateZoneLink checks that the target of a zone link exists and is not
+// itself a linked zone, since the API only rejects these after creation.
+func validateZoneLink(client *ns1.Client, target string) error {
+	z, _, err := client.Zones.Get(target)
+	if err != nil {
+		if err == ns1.ErrZoneMissing {
+			return fmt.Errorf("cannot link to zone %q: zone does not exist", target)
+		}
+		return err
+	}
+	if z.Link != nil && *z.Link != "" {
+		return fmt.Errorf("cannot link to zone %q: it is itself linked to %q", target, *z.Link)
+	}
+	return nil
+}
+
+// zoneLinks returns the names of the zones that link to the given zone, and
+// the records of other zones that link to records of the given zone. Finding
+// the records takes a request for each zone of the account.
+func zoneLinks(client *ns1.Client, z *dns.Zone) (zones []string, records []string, err error) {
+	zl, _, err := client.Zones.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	zones = make([]string, 0)
+	others := make([]*dns.Zone, 0, len(zl))
+	for _, other := range zl {
+		if other.Zone == z.Zone {
+			continue
+		}
+		if other.Link != nil && *other.Link != "" {
+			if *other.Link == z.Zone {
+				zones = append(zones, other.Zone)
+			}
+			continue
+		}
+		other, _, err := client.Zones.Get(other.Zone)
+		if err != nil {
+			return nil, nil, err
+		}
+		others = append(others, other)
+	}
+	return zones, linkedRecords(z, others), nil
+}
+
+// linkedRecords returns the records of the other zones that link to records
+// of the given zone. A linked record takes its answers from the record with
+// the domain it links to and its own type.
+func linkedRecords(z *dns.Zone, others []*dns.Zone) []string {
+	targets := make(map[string]bool, len(z.Records))
+	for _, r := range z.Records {
+		targets[fmt.Sprintf("%s/%s", r.Domain, r.Type)] = true
+	}
+	records := make([]string, 0)
+	for _, other := range others {
+		for _, r := range other.Records {
+			if r.Link != "" && targets[fmt.Sprintf("%s/%s", r.Link, r.Type)] {
+				records = append(records, fmt.Sprintf("%s/%s", r.Domain, r.Type))
+			}
+		}
+	}
+	return records
+}
+
 // ZoneCreate creates the given zone in ns1
 func ZoneCreate(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if v, ok := d.GetOk("link"); ok {
+		if err := validateZoneLink(client, v.(string)); err != nil {
+			return err
+		}
+	}
 	z := dns.NewZone(d.Get("zone").(string))
 	resourceToZoneData(z, d)
 	if _, err := client.Zones.Create(z); err != nil {
 		return err
 	}
 	zoneToResourceData(d, z)
+	if d.Get("dnssec").(bool) {
+		if _, err := newDNSSECService(client).SetEnabled(z.Zone, true); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 // ZoneRead reads the given zone data from ns1
 func ZoneRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
-	z, _, err := client.Zones.Get(d.Get("zone").(string))
+	z, _, err := newDNSSECService(client).GetZone(d.Get("zone").(string))
 	if err != nil {
 		return err
 	}
-	zoneToResourceData(d, z)
+	zoneToResourceData(d, &z.Zone)
+	d.Set("dnssec", z.DNSSEC)
+	if d.Get("list_linked_zones").(bool) {
+		zones, records, err := zoneLinks(client, &z.Zone)
+		if err != nil {
+			return err
+		}
+		d.Set("linked_zones", zones)
+		d.Set("linked_records", records)
+	} else {
+		d.Set("linked_zones", []string{})
+		d.Set("linked_records", []string{})
+	}
 	return nil
 }
 
+// unmanagedRecords returns the records left in a zone that is about to be
+// deleted. Records managed by terraform are destroyed before the zone they
+// depend on, so anything remaining apart from the apex NS record that NS1
+// creates with the zone was created elsewhere. Linked zones hold no records
+// of their own, and the records of secondary zones are transferred from their
+// primary, so deleting either never loses data.
+func unmanagedRecords(z *dns.Zone) []string {
+	records := make([]string, 0)
+	if z.Link != nil && *z.Link != "" {
+		return records
+	}
+	if z.Secondary != nil && z.Secondary.Enabled {
+		return records
+	}
+	for _, r := range z.Records {
+		if r.Domain == z.Zone && r.Type == "NS" {
+			continue
+		}
+		records = append(records, fmt.Sprintf("%s/%s", r.Domain, r.Type))
+	}
+	return records
+}
+
 // ZoneDelete deteles the given zone from ns1
 func ZoneDelete(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ns1.Client)
+	if !d.Get("force_destroy").(bool) {
+		z, _, err := client.Zones.Get(d.Get("zone").(string))
+		if err == ns1.ErrZoneMissing {
+			log.Printf("[WARN] Zone %s is already deleted", d.Get("zone"))
+			d.SetId("")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if records := unmanagedRecords(z); len(records) > 0 {
+			return fmt.Errorf(
+				"zone %s contains records not managed by terraform, set force_destroy to delete it anyway: %s",
+				z.Zone, strings.Join(records, ", "))
+		}
+	}
+	// The links are as of the last refresh, which only finds them if
+	// list_linked_zones is set.
+	if linked := d.Get("linked_zones").([]interface{}); len(linked) > 0 {
+		log.Printf("[WARN] Deleting zone %s, which is the link target of the zones: %v", d.Get("zone"), linked)
+	}
+	if linked := d.Get("linked_records").([]interface{}); len(linked) > 0 {
+		log.Printf("[WARN] Deleting zone %s, which is the link target of the records: %v", d.Get("zone"), linked)
+	}
 	_, err := client.Zones.Delete(d.Get("zone").(string))
+	if err == ns1.ErrZoneMissing {
+		err = nil
+	}
 	d.SetId("")
 	return err
 }
@@ -179,10 +364,17 @@ func ZoneUpdate(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 	zoneToResourceData(d, z)
+	if d.HasChange("dnssec") {
+		if _, err := newDNSSECService(client).SetEnabled(z.Zone, d.Get("dnssec").(bool)); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
 func ZoneStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	d.Set("zone", d.Id())
+	d.Set("force_destroy", false)
+	d.Set("list_linked_zones", false)
 	return []*schema.ResourceData{d}, nil
 }
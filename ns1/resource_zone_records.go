@@ -0,0 +1,334 @@
+package ns1
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func zoneRecordsResource() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			// Required
+			"zone": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// Optional
+			"records": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"domain": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: recordTypeStringEnum.ValidateFunc,
+						},
+						"ttl": {
+							Type:     schema.TypeInt,
+							Optional: true,
+							Computed: true,
+						},
+						"link": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"answers": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			// Computed
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+		Create:   ZoneRecordsCreate,
+		Read:     ZoneRecordsRead,
+		Update:   ZoneRecordsUpdate,
+		Delete:   ZoneRecordsDelete,
+		Importer: &schema.ResourceImporter{State: ZoneRecordsStateFunc},
+	}
+}
+
+// zoneRecordKey identifies a record within a zone.
+func zoneRecordKey(domain, t string) string {
+	return fmt.Sprintf("%s/%s", domain, t)
+}
+
+// isZoneApexNS reports whether r is the apex NS record that NS1 creates along
+// with the zone. It is left alone unless it is explicitly declared.
+func isZoneApexNS(zone string, r *dns.ZoneRecord) bool {
+	return r.Domain == zone && r.Type == "NS"
+}
+
+func zoneRecordToMap(r *dns.ZoneRecord) map[string]interface{} {
+	m := make(map[string]interface{})
+	m["domain"] = r.Domain
+	m["type"] = r.Type
+	m["ttl"] = r.TTL
+	if r.Link != "" {
+		m["link"] = r.Link
+	}
+	if r.ShortAns != nil {
+		m["answers"] = r.ShortAns
+	}
+	return m
+}
+
+// resourceDataToZoneRecords returns the declared records keyed by
+// zoneRecordKey, along with the keys in declaration order.
+func resourceDataToZoneRecords(d *schema.ResourceData) (map[string]*dns.Record, []string, error) {
+	zone := d.Get("zone").(string)
+	records := make(map[string]*dns.Record)
+	keys := make([]string, 0)
+	for _, recordRaw := range d.Get("records").([]interface{}) {
+		rm := recordRaw.(map[string]interface{})
+		domain := rm["domain"].(string)
+		if domain != zone && !strings.HasSuffix(domain, "."+zone) {
+			return nil, nil, fmt.Errorf("record domain %q is not within zone %q", domain, zone)
+		}
+		r := dns.NewRecord(zone, domain, rm["type"].(string))
+		key := zoneRecordKey(r.Domain, r.Type)
+		if _, ok := records[key]; ok {
+			return nil, nil, fmt.Errorf("record %s is declared more than once", key)
+		}
+		if v, ok := rm["ttl"]; ok {
+			r.TTL = v.(int)
+		}
+		answers := rm["answers"].([]interface{})
+		if v, ok := rm["link"]; ok && v.(string) != "" {
+			if len(answers) > 0 {
+				return nil, nil, fmt.Errorf("record %s cannot have both link and answers", key)
+			}
+			r.LinkTo(v.(string))
+		}
+		for _, answerRaw := range answers {
+			switch r.Type {
+			case "TXT", "SPF":
+				r.AddAnswer(dns.NewTXTAnswer(answerRaw.(string)))
+			default:
+				r.AddAnswer(dns.NewAnswer(strings.Split(answerRaw.(string), " ")))
+			}
+		}
+		records[key] = r
+		keys = append(keys, key)
+	}
+	return records, keys, nil
+}
+
+// zoneRecordChanged reports whether the live record differs from the
+// declared one in any of the attributes managed by ns1_zone_records.
+func zoneRecordChanged(current *dns.ZoneRecord, desired *dns.Record) bool {
+	if desired.TTL != 0 && current.TTL != desired.TTL {
+		return true
+	}
+	if current.Link != desired.Link {
+		return true
+	}
+	answers := make([]string, len(desired.Answers))
+	for i, a := range desired.Answers {
+		answers[i] = a.String()
+	}
+	shortAns := current.ShortAns
+	if shortAns == nil {
+		shortAns = []string{}
+	}
+	return !reflect.DeepEqual(shortAns, answers)
+}
+
+// zoneRecordsPlan returns the keys of the current records to delete, since
+// they are not declared, and of the declared records to create and to update,
+// in declaration order.
+func zoneRecordsPlan(zone string, desired map[string]*dns.Record, keys []string, current map[string]*dns.ZoneRecord) (deletes, creates, updates []string) {
+	deletes, creates, updates = []string{}, []string{}, []string{}
+	for key, r := range current {
+		if _, ok := desired[key]; ok || isZoneApexNS(zone, r) {
+			continue
+		}
+		deletes = append(deletes, key)
+	}
+	sort.Strings(deletes)
+	for _, key := range keys {
+		cur, ok := current[key]
+		switch {
+		case !ok:
+			creates = append(creates, key)
+		case zoneRecordChanged(cur, desired[key]):
+			updates = append(updates, key)
+		}
+	}
+	return deletes, creates, updates
+}
+
+// reconcileZoneRecords makes the records in the zone match the declared
+// set: records that are not declared are deleted, missing records created,
+// and changed ones updated. Deleting comes first, since the API rejects some
+// records next to others with the same name, such as a CNAME replacing an A
+// record.
+func reconcileZoneRecords(d *schema.ResourceData, client *ns1.Client) error {
+	zone := d.Get("zone").(string)
+	desired, keys, err := resourceDataToZoneRecords(d)
+	if err != nil {
+		return err
+	}
+
+	z, _, err := client.Zones.Get(zone)
+	if err != nil {
+		return err
+	}
+	current := make(map[string]*dns.ZoneRecord)
+	for _, r := range z.Records {
+		current[zoneRecordKey(r.Domain, r.Type)] = r
+	}
+	deletes, creates, updates := zoneRecordsPlan(zone, desired, keys, current)
+
+	for _, key := range deletes {
+		r := current[key]
+		log.Printf("[DEBUG] Deleting undeclared record %s in zone %s", key, zone)
+		if _, err := client.Records.Delete(zone, r.Domain, r.Type); err != nil {
+			return fmt.Errorf("error deleting record %s: %s", key, err)
+		}
+	}
+
+	for _, key := range creates {
+		log.Printf("[DEBUG] Creating record %s in zone %s", key, zone)
+		if _, err := client.Records.Create(desired[key]); err != nil {
+			return fmt.Errorf("error creating record %s: %s", key, err)
+		}
+	}
+
+	for _, key := range updates {
+		r := desired[key]
+		// Start from the live record so that meta, filters and regions
+		// managed elsewhere are kept.
+		live, _, err := client.Records.Get(zone, r.Domain, r.Type)
+		if err != nil {
+			return err
+		}
+		if r.TTL != 0 {
+			live.TTL = r.TTL
+		}
+		if r.Link != "" {
+			live.LinkTo(r.Link)
+		} else {
+			live.Link = ""
+			live.Answers = r.Answers
+		}
+		log.Printf("[DEBUG] Updating record %s in zone %s", key, zone)
+		if _, err := client.Records.Update(live); err != nil {
+			return fmt.Errorf("error updating record %s: %s", key, err)
+		}
+	}
+	return nil
+}
+
+// ZoneRecordsCreate takes authoritative control of the records in a zone
+func ZoneRecordsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := reconcileZoneRecords(d, client); err != nil {
+		return err
+	}
+	d.SetId(d.Get("zone").(string))
+	return ZoneRecordsRead(d, meta)
+}
+
+// ZoneRecordsRead reads the complete record set of the zone from ns1
+func ZoneRecordsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	z, _, err := client.Zones.Get(d.Id())
+	if err != nil {
+		return err
+	}
+
+	// Keep the declared ordering so that the list does not show spurious
+	// diffs, and append anything else found in the zone so that it shows up
+	// as a pending deletion.
+	recordsRaw := d.Get("records").([]interface{})
+	declared := make(map[string]int)
+	for i, recordRaw := range recordsRaw {
+		rm := recordRaw.(map[string]interface{})
+		declared[zoneRecordKey(rm["domain"].(string), rm["type"].(string))] = i
+	}
+	ordered := make([]map[string]interface{}, len(recordsRaw))
+	extra := make([]map[string]interface{}, 0)
+	for _, r := range z.Records {
+		key := zoneRecordKey(r.Domain, r.Type)
+		if i, ok := declared[key]; ok {
+			ordered[i] = zoneRecordToMap(r)
+			continue
+		}
+		if isZoneApexNS(z.Zone, r) {
+			continue
+		}
+		extra = append(extra, zoneRecordToMap(r))
+	}
+	records := make([]map[string]interface{}, 0, len(ordered)+len(extra))
+	for _, m := range ordered {
+		// Declared records that have gone missing are dropped, which
+		// causes them to be recreated.
+		if m != nil {
+			records = append(records, m)
+		}
+	}
+	records = append(records, extra...)
+
+	d.Set("zone", z.Zone)
+	if err := d.Set("records", records); err != nil {
+		return fmt.Errorf("[DEBUG] Error setting records for: %s, error: %#v", z.Zone, err)
+	}
+	return nil
+}
+
+// ZoneRecordsUpdate reconciles the records in the zone with the declared set
+func ZoneRecordsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	if err := reconcileZoneRecords(d, client); err != nil {
+		return err
+	}
+	return ZoneRecordsRead(d, meta)
+}
+
+// ZoneRecordsDelete deletes the declared records from the zone
+func ZoneRecordsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ns1.Client)
+	zone := d.Get("zone").(string)
+	records, keys, err := resourceDataToZoneRecords(d)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		r := records[key]
+		if r.Domain == zone && r.Type == "NS" {
+			continue
+		}
+		_, err := client.Records.Delete(zone, r.Domain, r.Type)
+		if err != nil && err != ns1.ErrRecordMissing {
+			return fmt.Errorf("error deleting record %s: %s", key, err)
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+func ZoneRecordsStateFunc(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("zone", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,214 @@
+package ns1
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	ns1 "gopkg.in/ns1/ns1-go.v2/rest"
+	"gopkg.in/ns1/ns1-go.v2/rest/model/dns"
+)
+
+func TestZoneRecordsPlan(t *testing.T) {
+	zone := "terraform-test-zone.io"
+	record := func(domain, t string, answers ...string) *dns.Record {
+		r := dns.NewRecord(zone, domain, t)
+		for _, a := range answers {
+			r.AddAnswer(dns.NewAnswer([]string{a}))
+		}
+		return r
+	}
+	current := map[string]*dns.ZoneRecord{
+		"terraform-test-zone.io/NS": {Domain: zone, Type: "NS", ShortAns: []string{"dns1.p01.nsone.net."}},
+		"terraform-test-zone.io/A":  {Domain: zone, Type: "A", ShortAns: []string{"192.0.2.1"}},
+		"www.terraform-test-zone.io/A": {
+			Domain: "www.terraform-test-zone.io", Type: "A", ShortAns: []string{"192.0.2.1"},
+		},
+		"mail.terraform-test-zone.io/A": {
+			Domain: "mail.terraform-test-zone.io", Type: "A", ShortAns: []string{"192.0.2.2"},
+		},
+	}
+	cases := map[string]struct {
+		Desired []*dns.Record
+		Deletes []string
+		Creates []string
+		Updates []string
+	}{
+		"unchanged": {
+			Desired: []*dns.Record{
+				record(zone, "A", "192.0.2.1"),
+				record("www.terraform-test-zone.io", "A", "192.0.2.1"),
+				record("mail.terraform-test-zone.io", "A", "192.0.2.2"),
+			},
+			Deletes: []string{},
+			Creates: []string{},
+			Updates: []string{},
+		},
+		"type_change": {
+			// The A record is deleted before the CNAME is created.
+			Desired: []*dns.Record{
+				record(zone, "A", "192.0.2.1"),
+				record("www.terraform-test-zone.io", "CNAME", "terraform-test-zone.io"),
+				record("mail.terraform-test-zone.io", "A", "192.0.2.3"),
+			},
+			Deletes: []string{"www.terraform-test-zone.io/A"},
+			Creates: []string{"www.terraform-test-zone.io/CNAME"},
+			Updates: []string{"mail.terraform-test-zone.io/A"},
+		},
+		"empty": {
+			// The apex NS record is kept unless declared.
+			Desired: []*dns.Record{},
+			Deletes: []string{
+				"mail.terraform-test-zone.io/A",
+				"terraform-test-zone.io/A",
+				"www.terraform-test-zone.io/A",
+			},
+			Creates: []string{},
+			Updates: []string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		desired := make(map[string]*dns.Record)
+		keys := make([]string, 0)
+		for _, r := range tc.Desired {
+			key := zoneRecordKey(r.Domain, r.Type)
+			desired[key] = r
+			keys = append(keys, key)
+		}
+		deletes, creates, updates := zoneRecordsPlan(zone, desired, keys, current)
+		if !reflect.DeepEqual(deletes, tc.Deletes) {
+			t.Fatalf("bad: %s: deletes\n\n expected: %#v\n got: %#v", tn, tc.Deletes, deletes)
+		}
+		if !reflect.DeepEqual(creates, tc.Creates) {
+			t.Fatalf("bad: %s: creates\n\n expected: %#v\n got: %#v", tn, tc.Creates, creates)
+		}
+		if !reflect.DeepEqual(updates, tc.Updates) {
+			t.Fatalf("bad: %s: updates\n\n expected: %#v\n got: %#v", tn, tc.Updates, updates)
+		}
+	}
+}
+
+func TestAccZoneRecords_basic(t *testing.T) {
+	var zone dns.Zone
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneRecordsBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckZoneExists("ns1_zone.test", &zone),
+					testAccCheckZoneRecordsAnswers("terraform-zone-records-test.io", "www.terraform-zone-records-test.io", "A", []string{"1.2.3.4", "1.2.3.5"}),
+					testAccCheckZoneRecordsAnswers("terraform-zone-records-test.io", "terraform-zone-records-test.io", "MX", []string{"10 mail.terraform-zone-records-test.io"}),
+					resource.TestCheckResourceAttr("ns1_zone_records.it", "records.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccZoneRecords_updated(t *testing.T) {
+	var zone dns.Zone
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneRecordsBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckZoneExists("ns1_zone.test", &zone),
+					testAccCreateUnmanagedRecord(&zone, "unmanaged"),
+				),
+				// The unmanaged record shows up as a pending deletion.
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccZoneRecordsUpdated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckZoneRecordsAnswers("terraform-zone-records-test.io", "www.terraform-zone-records-test.io", "A", []string{"5.6.7.8"}),
+					testAccCheckZoneRecordsAbsent("terraform-zone-records-test.io", "terraform-zone-records-test.io", "MX"),
+					testAccCheckZoneRecordsAbsent("terraform-zone-records-test.io", "unmanaged.terraform-zone-records-test.io", "A"),
+					resource.TestCheckResourceAttr("ns1_zone_records.it", "records.#", "1"),
+					resource.TestCheckResourceAttr("ns1_zone_records.it", "records.0.ttl", "120"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckZoneRecordsAnswers(zone, domain, t string, expected []string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		r, _, err := client.Records.Get(zone, domain, t)
+		if err != nil {
+			return err
+		}
+
+		answers := make([]string, len(r.Answers))
+		for i, a := range r.Answers {
+			answers[i] = a.String()
+		}
+		if !reflect.DeepEqual(answers, expected) {
+			return fmt.Errorf("%s/%s answers: got: %v want: %v", domain, t, answers, expected)
+		}
+		return nil
+	}
+}
+
+func testAccCheckZoneRecordsAbsent(zone, domain, t string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		if _, _, err := client.Records.Get(zone, domain, t); err != ns1.ErrRecordMissing {
+			return fmt.Errorf("%s/%s: expected record to be deleted, got: %v", domain, t, err)
+		}
+		return nil
+	}
+}
+
+const testAccZoneRecordsBasic = `
+resource "ns1_zone_records" "it" {
+  zone = "${ns1_zone.test.zone}"
+
+  records {
+    domain  = "www.${ns1_zone.test.zone}"
+    type    = "A"
+    ttl     = 60
+    answers = ["1.2.3.4", "1.2.3.5"]
+  }
+
+  records {
+    domain  = "${ns1_zone.test.zone}"
+    type    = "MX"
+    answers = ["10 mail.${ns1_zone.test.zone}"]
+  }
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-zone-records-test.io"
+}
+`
+
+const testAccZoneRecordsUpdated = `
+resource "ns1_zone_records" "it" {
+  zone = "${ns1_zone.test.zone}"
+
+  records {
+    domain  = "www.${ns1_zone.test.zone}"
+    type    = "A"
+    ttl     = 120
+    answers = ["5.6.7.8"]
+  }
+}
+
+resource "ns1_zone" "test" {
+  zone = "terraform-zone-records-test.io"
+}
+`
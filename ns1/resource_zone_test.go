@@ -2,6 +2,10 @@ package ns1
 
 import (
 	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/resource"
@@ -28,6 +32,8 @@ func TestAccZone_basic(t *testing.T) {
 					testAccCheckZoneRetry(&zone, 7200),
 					testAccCheckZoneExpiry(&zone, 1209600),
 					testAccCheckZoneNxTTL(&zone, 3600),
+					testAccCheckZoneDNSServers("ns1_zone.it", &zone),
+					resource.TestCheckResourceAttrSet("ns1_zone.it", "serial"),
 				),
 			},
 		},
@@ -69,6 +75,169 @@ func TestAccZone_updated(t *testing.T) {
 	})
 }
 
+func TestUnmanagedRecords(t *testing.T) {
+	link := "terraform-test-zone.io"
+	records := []*dns.ZoneRecord{
+		{Domain: "terraform-test-zone.io", Type: "NS"},
+		{Domain: "terraform-test-zone.io", Type: "A"},
+		{Domain: "www.terraform-test-zone.io", Type: "CNAME"},
+	}
+	cases := map[string]struct {
+		Zone     *dns.Zone
+		Expected []string
+	}{
+		"primary": {
+			Zone: &dns.Zone{Zone: "terraform-test-zone.io", Records: records},
+			Expected: []string{
+				"terraform-test-zone.io/A",
+				"www.terraform-test-zone.io/CNAME",
+			},
+		},
+		"linked": {
+			Zone:     &dns.Zone{Zone: "terraform-linked-test-zone.io", Link: &link, Records: records},
+			Expected: []string{},
+		},
+		"secondary": {
+			Zone: &dns.Zone{
+				Zone:      "terraform-test-zone.io",
+				Secondary: &dns.ZoneSecondary{Enabled: true, PrimaryIP: "192.0.2.1"},
+				Records:   records,
+			},
+			Expected: []string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := unmanagedRecords(tc.Zone); !reflect.DeepEqual(got, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, got)
+		}
+	}
+}
+
+func TestLinkedRecords(t *testing.T) {
+	zone := &dns.Zone{
+		Zone: "terraform-test-zone.io",
+		Records: []*dns.ZoneRecord{
+			{Domain: "terraform-test-zone.io", Type: "NS"},
+			{Domain: "www.terraform-test-zone.io", Type: "A"},
+		},
+	}
+	cases := map[string]struct {
+		Others   []*dns.Zone
+		Expected []string
+	}{
+		"none": {
+			Others:   []*dns.Zone{},
+			Expected: []string{},
+		},
+		"linked": {
+			Others: []*dns.Zone{{
+				Zone: "terraform-other-test-zone.io",
+				Records: []*dns.ZoneRecord{
+					{Domain: "terraform-other-test-zone.io", Type: "NS"},
+					{Domain: "www.terraform-other-test-zone.io", Type: "A", Link: "www.terraform-test-zone.io"},
+				},
+			}},
+			Expected: []string{"www.terraform-other-test-zone.io/A"},
+		},
+		"other_type": {
+			Others: []*dns.Zone{{
+				Zone: "terraform-other-test-zone.io",
+				Records: []*dns.ZoneRecord{
+					{Domain: "www.terraform-other-test-zone.io", Type: "AAAA", Link: "www.terraform-test-zone.io"},
+				},
+			}},
+			Expected: []string{},
+		},
+		"other_target": {
+			Others: []*dns.Zone{{
+				Zone: "terraform-other-test-zone.io",
+				Records: []*dns.ZoneRecord{
+					{Domain: "www.terraform-other-test-zone.io", Type: "A", Link: "www.example.com"},
+				},
+			}},
+			Expected: []string{},
+		},
+	}
+
+	for tn, tc := range cases {
+		if got := linkedRecords(zone, tc.Others); !reflect.DeepEqual(got, tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, got)
+		}
+	}
+}
+
+func TestAccZone_linked(t *testing.T) {
+	var zone dns.Zone
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneLinked,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckZoneExists("ns1_zone.linked", &zone),
+					testAccCheckZoneLink(&zone, "terraform-test-zone.io"),
+				),
+			},
+			{
+				// The links are only known once the source zone is refreshed.
+				Config: testAccZoneLinked,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_zone.it", "linked_zones.#", "1"),
+					resource.TestCheckResourceAttr("ns1_zone.it", "linked_zones.0", "terraform-linked-test-zone.io"),
+					resource.TestCheckResourceAttr("ns1_zone.it", "linked_records.#", "1"),
+					resource.TestCheckResourceAttr("ns1_zone.it", "linked_records.0", "www.terraform-other-test-zone.io/A"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccZone_linkMissing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccZoneLinkMissing,
+				ExpectError: regexp.MustCompile(`cannot link to zone "terraform-missing-test-zone.io": zone does not exist`),
+			},
+		},
+	})
+}
+
+func TestAccZone_forceDestroy(t *testing.T) {
+	var zone dns.Zone
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckZoneDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccZoneBasic,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckZoneExists("ns1_zone.it", &zone),
+					testAccCreateUnmanagedRecord(&zone, "unmanaged"),
+				),
+			},
+			{
+				Config:      testAccZoneBasic,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`contains records not managed by terraform.*unmanaged\.terraform-test-zone\.io/A`),
+			},
+			{
+				Config: testAccZoneForceDestroy,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("ns1_zone.it", "force_destroy", "true"),
+				),
+			},
+		},
+	})
+}
+
 func testAccCheckZoneExists(n string, zone *dns.Zone) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -169,6 +338,51 @@ func testAccCheckZoneNxTTL(zone *dns.Zone, expected int) resource.TestCheckFunc
 	}
 }
 
+func testAccCheckZoneLink(zone *dns.Zone, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if zone.Link == nil || *zone.Link != expected {
+			return fmt.Errorf("Link: got: %v want: %s", zone.Link, expected)
+		}
+		return nil
+	}
+}
+
+// testAccCreateUnmanagedRecord adds a record to the zone behind terraform's back.
+func testAccCreateUnmanagedRecord(zone *dns.Zone, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*ns1.Client)
+
+		r := dns.NewRecord(zone.Zone, name+"."+zone.Zone, "A")
+		r.AddAnswer(dns.NewAv4Answer("1.2.3.4"))
+		_, err := client.Records.Create(r)
+		return err
+	}
+}
+
+func testAccCheckZoneDNSServers(n string, zone *dns.Zone) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		p := rs.Primary
+		if p.Attributes["dns_servers"] != strings.Join(zone.DNSServers, ",") {
+			return fmt.Errorf("dns_servers: got: %s want: %s", p.Attributes["dns_servers"], strings.Join(zone.DNSServers, ","))
+		}
+		if p.Attributes["dns_servers_list.#"] != strconv.Itoa(len(zone.DNSServers)) {
+			return fmt.Errorf("dns_servers_list.#: got: %s want: %d", p.Attributes["dns_servers_list.#"], len(zone.DNSServers))
+		}
+		for i, server := range zone.DNSServers {
+			k := fmt.Sprintf("dns_servers_list.%d", i)
+			if p.Attributes[k] != server {
+				return fmt.Errorf("%s: got: %s want: %s", k, p.Attributes[k], server)
+			}
+		}
+		return nil
+	}
+}
+
 const testAccZoneBasic = `
 resource "ns1_zone" "it" {
   zone = "terraform-test-zone.io"
@@ -183,7 +397,52 @@ resource "ns1_zone" "it" {
   retry   = 300
   expiry  = 2592000
   nx_ttl  = 3601
-  # link    = "1.2.3.4.in-addr.arpa" # TODO
   # primary = "1.2.3.4.in-addr.arpa" # TODO
 }
 `
+
+const testAccZoneForceDestroy = `
+resource "ns1_zone" "it" {
+  zone          = "terraform-test-zone.io"
+  force_destroy = true
+}
+`
+
+const testAccZoneLinked = `
+resource "ns1_zone" "it" {
+  zone              = "terraform-test-zone.io"
+  list_linked_zones = true
+}
+
+resource "ns1_zone" "linked" {
+  zone = "terraform-linked-test-zone.io"
+  link = "${ns1_zone.it.zone}"
+}
+
+resource "ns1_record" "target" {
+  zone   = "${ns1_zone.it.zone}"
+  domain = "www.${ns1_zone.it.zone}"
+  type   = "A"
+  answers {
+    answer = "192.0.2.1"
+  }
+}
+
+resource "ns1_zone" "other" {
+  zone = "terraform-other-test-zone.io"
+}
+
+resource "ns1_record" "linked" {
+  zone   = "${ns1_zone.other.zone}"
+  domain = "www.${ns1_zone.other.zone}"
+  type   = "A"
+  link   = "${ns1_record.target.domain}"
+}
+`
+
+const testAccZoneLinkMissing = `
+resource "ns1_zone" "linked" {
+  zone = "terraform-linked-test-zone.io"
+  link = "terraform-missing-test-zone.io"
+}
+`